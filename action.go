@@ -266,6 +266,8 @@ const (
 	panicDataKey           = privatekey.PanicData
 	optionalAliasesDataKey = privatekey.OptionalAliases
 	dependsOnDataKey       = privatekey.DependsOn
+	lookupEnvDataKey       = privatekey.LookupEnv
+	valueSourcesDataKey    = privatekey.ValueSources
 )
 
 const (
@@ -463,7 +465,8 @@ var (
 
 	globals = struct {
 		sync.RWMutex
-		uses []Action
+		uses     []Action
+		registry []registration
 	}{}
 )
 
@@ -1392,9 +1395,13 @@ func optionalSetup(a func(*Context)) ActionFunc {
 // the error ErrImplicitValueAlreadySet could be generated from setting value
 // but it is also ignored.
 func ImplicitValue(fn func(*Context) (string, bool)) Action {
+	return implicitValueFrom(ValueSource{Kind: ImpliedSource}, fn)
+}
+
+func implicitValueFrom(source ValueSource, fn func(*Context) (string, bool)) Action {
 	return Implicitly(ActionFunc(func(c *Context) error {
 		if v, ok := fn(c); ok {
-			c.SetValue(v)
+			c.SetValueFrom(source, v)
 		}
 		return nil
 	}))
@@ -1424,7 +1431,8 @@ func Implies(name, value string) Action {
 		name = "--" + name
 	}
 	return ActionFunc(func(c *Context) error {
-		return c.Parent().HookBefore(name, ImplicitValue(func(_ *Context) (string, bool) {
+		source := ValueSource{Kind: ImpliedSource, Name: c.Name()}
+		return c.Parent().HookBefore(name, implicitValueFrom(source, func(_ *Context) (string, bool) {
 			if c.Occurrences("") == 0 {
 				return "", false
 			}
@@ -1679,8 +1687,8 @@ func FromEnv(vars ...string) Action {
 			// This addresses the case where Boolean flags are typically interpreted
 			// as true even when empty (i.e. --bool is the same as -bool=true and perhaps
 			// surprisingly --bool= ).  But ENV_VAR= is not treated as true if present.
-			if val := os.Getenv(envVar); val != "" {
-				c.SetValue(val)
+			if val, _ := c.LookupEnv(envVar); val != "" {
+				c.SetValueFrom(ValueSource{Kind: EnvSource, Name: envVar}, val)
 				return nil
 			}
 		}
@@ -1688,6 +1696,18 @@ func FromEnv(vars ...string) Action {
 	}))
 }
 
+// SetLookupEnv provides an action which sets the function used to look up environment
+// variables within the command and its sub-commands.  Values from the function take precedence
+// over the process environment, which is used when the function returns false.  The function
+// is consulted by FromEnv and the EnvVars of flags and args.  This allows an extension to
+// provide environment variables, such as those from a project-local file, without
+// mutating the process environment.
+func SetLookupEnv(fn func(string) (string, bool)) Action {
+	return ActionFunc(func(c *Context) error {
+		return c.SetData(lookupEnvDataKey, fn)
+	})
+}
+
 func flagScreamingSnakeCase(o option) string {
 	name := o.name()
 	if f, ok := o.(*Flag); ok {
@@ -1699,6 +1719,7 @@ func flagScreamingSnakeCase(o option) string {
 }
 
 // FromFilePath loads the value from the given file path.
+// Trailing newlines are removed from the contents of the file.
 // If the file does not exist or fails to load, the error is
 // silently ignored.
 // Alternatively, you can set the Flag or Arg field FilePath.
@@ -1713,7 +1734,7 @@ func FromFilePath(f fs.FS, filePath string) Action {
 		if len(filePath) > 0 {
 			data, err := fs.ReadFile(f, filePath)
 			if err == nil {
-				c.SetValue(string(data))
+				c.SetValueFrom(ValueSource{Kind: FileSource, Name: filePath}, strings.TrimRight(string(data), "\r\n"))
 				return nil
 			}
 		}
@@ -4312,6 +4312,28 @@ var _ = Describe("FromEnv", Ordered, func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(value).To(BeFalse())
 	})
+
+	It("uses the function from SetLookupEnv before the process environment", func() {
+		var nominal, flagName string
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.SetLookupEnv(func(name string) (string, bool) {
+				if name == "NOMINAL" {
+					return "from_lookup", true
+				}
+				return "", false
+			}),
+			Flags: []*cli.Flag{
+				{Name: "nominal", EnvVars: []string{"NOMINAL"}, Value: &nominal},
+				{Name: "flag-name", EnvVars: []string{"FLAG_NAME"}, Value: &flagName},
+			},
+			Action: func() {},
+		}
+		args, _ := cli.Split("app")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(nominal).To(Equal("from_lookup"))
+		Expect(flagName).To(Equal("flag_name"))
+	})
 })
 
 var _ = Describe("FromFilePath", func() {
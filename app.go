@@ -119,6 +119,9 @@ type App struct {
 
 	rootCommandCreator func() *Command
 	rootCommand        *Command
+
+	// versionDefaulted is set when the Version was not specified
+	versionDefaulted bool
 }
 
 var (
@@ -329,6 +332,7 @@ func setupDefaultData(c *Context) error {
 	}
 	if a.Version == "" {
 		a.Version = "0.0.0"
+		a.versionDefaulted = true
 	}
 	if a.BuildDate.IsZero() {
 		a.BuildDate = buildDate()
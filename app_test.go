@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/Carbonfrost/joe-cli"
 	"github.com/Carbonfrost/joe-cli/joe-clifakes"
@@ -471,3 +472,132 @@ var _ = Describe("Run", func() {
 		Expect(exitCode).To(Equal(3))
 	})
 })
+
+var _ = Describe("InteractiveShell", func() {
+
+	var (
+		stderr  *bytes.Buffer
+		verbose []bool
+		newApp  func(input string) *cli.App
+	)
+
+	BeforeEach(func() {
+		verbose = nil
+		stderr = new(bytes.Buffer)
+		newApp = func(input string) *cli.App {
+			return &cli.App{
+				Name:   "app",
+				Stdin:  strings.NewReader(input),
+				Stderr: stderr,
+				Flags: []*cli.Flag{
+					{Name: "verbose", Value: new(bool)},
+				},
+				Commands: []*cli.Command{
+					{
+						Name: "status",
+						Action: func(c *cli.Context) {
+							verbose = append(verbose, c.Bool("verbose"))
+						},
+					},
+					{Name: "stash"},
+				},
+				Action: cli.InteractiveShell(),
+			}
+		}
+	})
+
+	It("executes each line and preserves persistent flags between lines", func() {
+		app := newApp("status\nstatus --verbose\n\nstatus\n")
+		err := app.RunContext(context.Background(), []string{"app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verbose).To(Equal([]bool{false, true, true}))
+	})
+
+	It("resets the flags of sub-commands between lines", func() {
+		var short []bool
+		app := &cli.App{
+			Name:  "app",
+			Stdin: strings.NewReader("status --short\nstatus\n"),
+			Commands: []*cli.Command{
+				{
+					Name: "status",
+					Flags: []*cli.Flag{
+						{Name: "short", Value: new(bool)},
+					},
+					Action: func(c *cli.Context) {
+						short = append(short, c.Bool("short"))
+					},
+				},
+			},
+			Action: cli.InteractiveShell(),
+		}
+		err := app.RunContext(context.Background(), []string{"app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(short).To(Equal([]bool{true, false}))
+	})
+
+	It("keeps flags set on the command line which started the shell", func() {
+		app := newApp("status\nstatus\n")
+		err := app.RunContext(context.Background(), []string{"app", "--verbose"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verbose).To(Equal([]bool{true, true}))
+	})
+
+	It("does not accumulate list values between lines", func() {
+		var tags [][]string
+		app := &cli.App{
+			Name:  "app",
+			Stdin: strings.NewReader("tag --tag a\ntag --tag b\n"),
+			Commands: []*cli.Command{
+				{
+					Name: "tag",
+					Flags: []*cli.Flag{
+						{Name: "tag", Value: new([]string), Options: cli.Merge},
+					},
+					Action: func(c *cli.Context) {
+						tags = append(tags, c.List("tag"))
+					},
+				},
+			},
+			Action: cli.InteractiveShell(),
+		}
+		err := app.RunContext(context.Background(), []string{"app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags).To(Equal([][]string{{"a"}, {"b"}}))
+	})
+
+	It("reports errors and continues", func() {
+		app := newApp("unknown\nstatus\n")
+		err := app.RunContext(context.Background(), []string{"app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr.String()).To(ContainSubstring(`"unknown" is not a command`))
+		Expect(verbose).To(HaveLen(1))
+	})
+
+	It("stops on exit", func() {
+		app := newApp("status\nexit\nstatus\n")
+		err := app.RunContext(context.Background(), []string{"app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verbose).To(HaveLen(1))
+	})
+
+	It("trims the common prefix of completions by rune", func() {
+		actual := cli.CommonCompletion([]cli.CompletionItem{
+			{Type: cli.CompletionTypeToken, Value: "café"},
+			{Type: cli.CompletionTypeToken, Value: "cafè"},
+		})
+		Expect(actual).To(Equal("caf"))
+	})
+
+	DescribeTable("completion", func(line string, expected string) {
+		app := newApp("")
+		ctx, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cli.InteractiveShellComplete(cli.FromContext(ctx), line)).To(Equal(expected))
+	},
+		Entry("unique command", "stat", "status "),
+		Entry("common prefix", "s", "sta"),
+		Entry("no match", "x", "x"),
+		Entry("flag", "status --verb", "status --verbose "),
+	)
+})
@@ -27,6 +27,7 @@ type Arg struct {
 	EnvVars []string
 
 	// FilePath specifies a file that is loaded to provide the default value of the argument.
+	// Trailing newlines are removed from the contents of the file.
 	FilePath string
 
 	// HelpText contains text which briefly describes the usage of the argument.
@@ -121,7 +122,8 @@ type Arg struct {
 	// overview in cli.Transform for information.
 	Transform TransformFunc
 
-	bs BindingState
+	bs     BindingState
+	source *ValueSource
 }
 
 //counterfeiter:generate . ArgCounter
@@ -350,6 +352,10 @@ func (a Arg) actualArgCounter() ArgCounter {
 	switch value := a.Value.(type) {
 	case *[]string:
 		return ArgCount(TakeUntilNextFlag)
+	case CollectionValue:
+		if value.CollectionKind() != MapCollection {
+			return ArgCount(TakeUntilNextFlag)
+		}
 	case valueProvidesCounter:
 		return value.NewCounter()
 	}
@@ -378,6 +384,14 @@ func (a *Arg) filePath() string {
 	return a.FilePath
 }
 
+func (a *Arg) valueSource() *ValueSource {
+	return a.source
+}
+
+func (a *Arg) setValueSource(s *ValueSource) {
+	a.source = s
+}
+
 func (a *Arg) category() string {
 	return a.Category
 }
@@ -0,0 +1,399 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Carbonfrost/joe-cli/internal/support"
+	"github.com/Carbonfrost/joe-cli/internal/synopsis"
+)
+
+// CollectionKind identifies the kind of a generic collection value
+type CollectionKind int
+
+// Kinds of generic collection values
+const (
+	// ListCollection identifies a list created by ListOf
+	ListCollection CollectionKind = iota + 1
+	// SetCollection identifies a set created by SetOf
+	SetCollection
+	// MapCollection identifies a map created by MapOf
+	MapCollection
+)
+
+// CollectionValue is implemented by the generic collection values created
+// with ListOf, SetOf, and MapOf.  It can be used to inspect the types of
+// elements in the collection.
+type CollectionValue interface {
+	Value
+
+	// CollectionKind gets the kind of collection
+	CollectionKind() CollectionKind
+
+	// NewElem creates a pointer to a new element of the collection.  For maps,
+	// this is the value of an entry.
+	NewElem() any
+
+	// NewKey creates a pointer to a new key of a map.  For lists and sets,
+	// this is nil.
+	NewKey() any
+}
+
+// TypedList is a list of values of a built-in type.  Each occurrence of the flag
+// or arg appends to the list.  Unless DisableSplitting is set, commas separate
+// multiple values.
+type TypedList[T any] struct {
+	items            []T
+	disableSplitting bool
+}
+
+// TypedSet is a set of values of a built-in type.  It is like TypedList
+// except that duplicate values are ignored.  Order of first occurrence is
+// preserved.
+type TypedSet[T comparable] struct {
+	items            []T
+	index            map[T]struct{}
+	disableSplitting bool
+}
+
+// TypedMap is a map of keys to values of built-in types.  Entries are specified
+// using the syntax key=value.  Unless DisableSplitting is set, commas separate
+// multiple entries.
+type TypedMap[K comparable, V any] struct {
+	items            map[K]V
+	disableSplitting bool
+}
+
+// ListOf creates a list value whose elements are converted using the same rules
+// as the corresponding built-in value.  For example, ListOf[int]() converts
+// each element in the same way as Int().  The optional items provide the initial
+// value of the list, which is replaced on the first occurrence unless Merge is set.
+// It panics if T is not a supported flag type.
+func ListOf[T any](items ...T) *TypedList[T] {
+	mustSupportElem[T]()
+	return &TypedList[T]{
+		items: slices.Clone(items),
+	}
+}
+
+// SetOf creates a set value whose elements are converted using the same rules
+// as the corresponding built-in value.  The optional items provide the initial
+// value of the set, which is replaced on the first occurrence unless Merge is set.
+// It panics if T is not a supported flag type.
+func SetOf[T comparable](items ...T) *TypedSet[T] {
+	mustSupportElem[T]()
+	res := &TypedSet[T]{}
+	res.Reset()
+	for _, item := range items {
+		res.add(item)
+	}
+	return res
+}
+
+// MapOf creates a map value whose keys and values are converted using the same rules
+// as the corresponding built-in values.  For example, MapOf[string, int]() accepts
+// the syntax name=1.  It panics if K or V is not a supported flag type.
+func MapOf[K comparable, V any]() *TypedMap[K, V] {
+	mustSupportElem[K]()
+	mustSupportElem[V]()
+	return &TypedMap[K, V]{
+		items: map[K]V{},
+	}
+}
+
+// Set will append the values to the list
+func (l *TypedList[T]) Set(arg string) error {
+	for _, s := range splitCollection(arg, l.disableSplitting) {
+		item, err := parseElem[T](s)
+		if err != nil {
+			return err
+		}
+		l.items = append(l.items, item)
+	}
+	return nil
+}
+
+// String gets the list as text
+func (l *TypedList[T]) String() string {
+	return formatElems(l.items)
+}
+
+// Value obtains the elements of the list
+func (l *TypedList[T]) Value() []T {
+	return l.items
+}
+
+// Reset empties the list
+func (l *TypedList[T]) Reset() {
+	l.items = nil
+}
+
+// Copy creates a copy of the list value
+func (l *TypedList[T]) Copy() *TypedList[T] {
+	return &TypedList[T]{
+		items:            slices.Clone(l.items),
+		disableSplitting: l.disableSplitting,
+	}
+}
+
+// DisableSplitting causes commas to be treated literally instead of as
+// separators between values
+func (l *TypedList[T]) DisableSplitting() {
+	l.disableSplitting = true
+}
+
+// Synopsis obtains the synopsis text
+func (l *TypedList[T]) Synopsis() string {
+	return pluralPlaceholder[T]()
+}
+
+// CollectionKind gets the kind of collection, which is ListCollection
+func (*TypedList[T]) CollectionKind() CollectionKind {
+	return ListCollection
+}
+
+// NewElem creates a pointer to a new element
+func (*TypedList[T]) NewElem() any {
+	return new(T)
+}
+
+// NewKey returns nil because lists do not have keys
+func (*TypedList[T]) NewKey() any {
+	return nil
+}
+
+func (l *TypedList[T]) setDirect(v any) error {
+	l.items = slices.Clone(v.([]T))
+	return nil
+}
+
+// Set will add the values to the set
+func (s *TypedSet[T]) Set(arg string) error {
+	for _, text := range splitCollection(arg, s.disableSplitting) {
+		item, err := parseElem[T](text)
+		if err != nil {
+			return err
+		}
+		s.add(item)
+	}
+	return nil
+}
+
+// String gets the set as text
+func (s *TypedSet[T]) String() string {
+	return formatElems(s.items)
+}
+
+// Value obtains the elements of the set in the order they were first added
+func (s *TypedSet[T]) Value() []T {
+	return s.items
+}
+
+// Contains determines whether the set contains the item
+func (s *TypedSet[T]) Contains(item T) bool {
+	_, ok := s.index[item]
+	return ok
+}
+
+// Reset empties the set
+func (s *TypedSet[T]) Reset() {
+	s.items = nil
+	s.index = map[T]struct{}{}
+}
+
+// Copy creates a copy of the set value
+func (s *TypedSet[T]) Copy() *TypedSet[T] {
+	return &TypedSet[T]{
+		items:            slices.Clone(s.items),
+		index:            maps.Clone(s.index),
+		disableSplitting: s.disableSplitting,
+	}
+}
+
+// DisableSplitting causes commas to be treated literally instead of as
+// separators between values
+func (s *TypedSet[T]) DisableSplitting() {
+	s.disableSplitting = true
+}
+
+// Synopsis obtains the synopsis text
+func (s *TypedSet[T]) Synopsis() string {
+	return pluralPlaceholder[T]()
+}
+
+// CollectionKind gets the kind of collection, which is SetCollection
+func (*TypedSet[T]) CollectionKind() CollectionKind {
+	return SetCollection
+}
+
+// NewElem creates a pointer to a new element
+func (*TypedSet[T]) NewElem() any {
+	return new(T)
+}
+
+// NewKey returns nil because sets do not have keys
+func (*TypedSet[T]) NewKey() any {
+	return nil
+}
+
+func (s *TypedSet[T]) setDirect(v any) error {
+	s.Reset()
+	for _, item := range v.([]T) {
+		s.add(item)
+	}
+	return nil
+}
+
+func (s *TypedSet[T]) add(item T) {
+	if _, ok := s.index[item]; ok {
+		return
+	}
+	s.index[item] = struct{}{}
+	s.items = append(s.items, item)
+}
+
+// Set will add the entries to the map
+func (m *TypedMap[K, V]) Set(arg string) error {
+	var entries map[string]string
+	if m.disableSplitting {
+		key, value, _ := support.ParseKeyValue(arg)
+		entries = map[string]string{key: value}
+	} else {
+		entries = support.FlattenValues(support.ParseMap(arg))
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(entries)) {
+		key, err := parseElem[K](k)
+		if err != nil {
+			return err
+		}
+		value, err := parseElem[V](entries[k])
+		if err != nil {
+			return err
+		}
+		m.items[key] = value
+	}
+	return nil
+}
+
+// String gets the map as text
+func (m *TypedMap[K, V]) String() string {
+	items := make([]string, 0, len(m.items))
+	for k, v := range m.items {
+		items = append(items, fmt.Sprint(k)+"="+fmt.Sprint(v))
+	}
+	slices.Sort(items)
+	return strings.Join(items, ",")
+}
+
+// Value obtains the entries of the map
+func (m *TypedMap[K, V]) Value() map[K]V {
+	return m.items
+}
+
+// Reset empties the map
+func (m *TypedMap[K, V]) Reset() {
+	m.items = map[K]V{}
+}
+
+// Copy creates a copy of the map value
+func (m *TypedMap[K, V]) Copy() *TypedMap[K, V] {
+	return &TypedMap[K, V]{
+		items:            maps.Clone(m.items),
+		disableSplitting: m.disableSplitting,
+	}
+}
+
+// DisableSplitting causes commas to be treated literally instead of as
+// separators between entries
+func (m *TypedMap[K, V]) DisableSplitting() {
+	m.disableSplitting = true
+}
+
+// Synopsis obtains the synopsis text
+func (m *TypedMap[K, V]) Synopsis() string {
+	key := synopsis.Placeholder(new(K))
+	if key == "STRING" {
+		key = "NAME"
+	}
+	value := synopsis.Placeholder(new(V))
+	if value == "STRING" {
+		value = "VALUE"
+	}
+	return key + "=" + value
+}
+
+// CollectionKind gets the kind of collection, which is MapCollection
+func (*TypedMap[K, V]) CollectionKind() CollectionKind {
+	return MapCollection
+}
+
+// NewElem creates a pointer to a new value of an entry
+func (*TypedMap[K, V]) NewElem() any {
+	return new(V)
+}
+
+// NewKey creates a pointer to a new key of an entry
+func (*TypedMap[K, V]) NewKey() any {
+	return new(K)
+}
+
+func (m *TypedMap[K, V]) setDirect(v any) error {
+	m.items = maps.Clone(v.(map[K]V))
+	return nil
+}
+
+func mustSupportElem[T any]() {
+	if err := checkSupportedFlagType(new(T)); err != nil {
+		panic(err)
+	}
+}
+
+// parseElem converts the text using the same conversion as the corresponding
+// built-in value
+func parseElem[T any](s string) (T, error) {
+	p := new(T)
+	if err := setCore(p, true, s); err != nil {
+		var zero T
+		return zero, err
+	}
+	return *p, nil
+}
+
+func splitCollection(arg string, disableSplitting bool) []string {
+	if disableSplitting {
+		return support.Unescape([]string{arg})
+	}
+	return support.Unescape(SplitList(arg, ",", -1))
+}
+
+func formatElems[T any](items []T) string {
+	res := make([]string, len(items))
+	for i, item := range items {
+		res[i] = fmt.Sprint(item)
+	}
+	return strings.Join(res, ",")
+}
+
+func pluralPlaceholder[T any]() string {
+	switch p := synopsis.Placeholder(new(T)); p {
+	case "STRING", "VALUE":
+		return "VALUES"
+	case "":
+		return "BOOLS"
+	default:
+		return p + "S"
+	}
+}
+
+var (
+	_ CollectionValue = (*TypedList[string])(nil)
+	_ CollectionValue = (*TypedSet[string])(nil)
+	_ CollectionValue = (*TypedMap[string, string])(nil)
+)
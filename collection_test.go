@@ -0,0 +1,167 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/bind"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("ListOf", func() {
+
+	DescribeTable("examples",
+		func(f *cli.Flag, arguments string, expected types.GomegaMatcher) {
+			act := new(joeclifakes.FakeAction)
+			app := &cli.App{
+				Name: "app",
+				Flags: []*cli.Flag{
+					f,
+				},
+				Action: act,
+			}
+
+			args, _ := cli.Split(arguments)
+			err := app.RunContext(context.Background(), args)
+			Expect(err).NotTo(HaveOccurred())
+			captured := cli.FromContext(act.ExecuteArgsForCall(0))
+			Expect(captured.Value("o")).To(expected)
+		},
+		Entry(
+			"int list",
+			&cli.Flag{Name: "o", Value: cli.ListOf[int]()},
+			"app -o 1 -o 2",
+			Equal([]int{1, 2}),
+		),
+		Entry(
+			"run-in",
+			&cli.Flag{Name: "o", Value: cli.ListOf[int]()},
+			"app -o 1,2,3 -o 4",
+			Equal([]int{1, 2, 3, 4}),
+		),
+		Entry(
+			"duration list",
+			&cli.Flag{Name: "o", Value: cli.ListOf[time.Duration]()},
+			"app -o 1s,2m",
+			Equal([]time.Duration{time.Second, 2 * time.Minute}),
+		),
+		Entry(
+			"disable splitting",
+			&cli.Flag{Name: "o", Value: cli.ListOf[string](), Options: cli.DisableSplitting},
+			"app -o a,b -o c",
+			Equal([]string{"a,b", "c"}),
+		),
+		Entry(
+			"resets initial values",
+			&cli.Flag{Name: "o", Value: cli.ListOf(1, 2)},
+			"app -o 3",
+			Equal([]int{3}),
+		),
+		Entry(
+			"merge",
+			&cli.Flag{Name: "o", Value: cli.ListOf(1, 2), Options: cli.Merge},
+			"app -o 3",
+			Equal([]int{1, 2, 3}),
+		),
+		Entry(
+			"set removes duplicates",
+			&cli.Flag{Name: "o", Value: cli.SetOf[int]()},
+			"app -o 2,1,2 -o 1,3",
+			Equal([]int{2, 1, 3}),
+		),
+		Entry(
+			"map",
+			&cli.Flag{Name: "o", Value: cli.MapOf[string, int]()},
+			"app -o a=1,b=2 -o c=3",
+			Equal(map[string]int{"a": 1, "b": 2, "c": 3}),
+		),
+		Entry(
+			"map disable splitting",
+			&cli.Flag{Name: "o", Value: cli.MapOf[string, string](), Options: cli.DisableSplitting},
+			"app -o a=1,2",
+			Equal(map[string]string{"a": "1,2"}),
+		),
+	)
+
+	It("takes args until the next flag", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "f", Value: cli.Bool()},
+			},
+			Args: []*cli.Arg{
+				{Name: "a", Value: cli.ListOf[int]()},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app 1 2 3 -f")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.Value("a")).To(Equal([]int{1, 2, 3}))
+	})
+
+	It("returns an error for an invalid element", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "o", Value: cli.ListOf[int]()},
+			},
+		}
+
+		args, _ := cli.Split("app -o 1,x")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).To(MatchError(ContainSubstring("not a valid number: x")))
+	})
+
+	It("can be bound", func() {
+		var actual []int
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "o", Value: cli.ListOf[int]()},
+			},
+			Action: bind.Call(func(v []int) error {
+				actual = v
+				return nil
+			}, bind.Value[[]int]("o")),
+		}
+
+		args, _ := cli.Split("app -o 4,5")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(actual).To(Equal([]int{4, 5}))
+	})
+
+	It("can be set directly", func() {
+		l := cli.ListOf[int]()
+		Expect(cli.Set(l, []int{1, 2}, "3")).To(Succeed())
+		Expect(l.Value()).To(Equal([]int{1, 2, 3}))
+	})
+
+	It("panics on unsupported element type", func() {
+		Expect(func() {
+			cli.ListOf[struct{}]()
+		}).To(Panic())
+	})
+
+	DescribeTable("synopsis",
+		func(v cli.Value, expected string) {
+			f := &cli.Flag{Name: "o", Value: v}
+			Expect(f.Synopsis()).To(Equal(expected))
+		},
+		Entry("string list", cli.ListOf[string](), "-o VALUES"),
+		Entry("int list", cli.ListOf[int](), "-o NUMBERS"),
+		Entry("duration set", cli.SetOf[time.Duration](), "-o DURATIONS"),
+		Entry("string map", cli.MapOf[string, string](), "-o NAME=VALUE"),
+		Entry("int map", cli.MapOf[string, int](), "-o NAME=NUMBER"),
+	)
+})
@@ -151,7 +151,7 @@ func ExecuteSubcommand(interceptErr func(context.Context, error) (*Command, erro
 		NArg:       -1,
 		Options:    DisableSplitting,
 		Completion: CompletionFunc(completeSubCommand),
-	}, At(ActionTiming, ActionFunc(func(c *Context) error {
+	}, setInternalFlag(internalFlagExecutesSubcommand), At(ActionTiming, ActionFunc(func(c *Context) error {
 		invoke := c.List("")
 		return subcommandCore(c, invoke, interceptErr)
 	})))
@@ -261,6 +261,149 @@ func ImplicitCommand(name string) CommandNotFoundHandler {
 	})
 }
 
+// AliasCommand creates a command which is an alias for other arguments.  When the
+// command is executed, its name is replaced with args, and the result is executed as
+// a sibling command.  Any arguments passed to the alias are appended.  For example, given
+// the alias "co" for "checkout -b", the invocation "app co topic" is equivalent to
+// "app checkout -b topic".  The first argument must name a sibling command, which cannot
+// itself be an alias.  AliasCommand can be returned from a CommandNotFoundHandler to
+// expand names which are not otherwise defined.
+func AliasCommand(name string, args ...string) *Command {
+	return &Command{
+		Name:     name,
+		HelpText: "Alias for " + Join(args),
+		Options:  SkipFlagParsing,
+		Data: map[string]any{
+			privatekey.CommandAlias: slices.Clone(args),
+		},
+		Args: []*Arg{
+			{
+				Name:    "args",
+				Value:   List(),
+				NArg:    -1,
+				Options: DisableSplitting,
+			},
+		},
+		Action: ActionFunc(func(c *Context) error {
+			return executeAlias(c, append(slices.Clone(args), c.List("args")...))
+		}),
+	}
+}
+
+func executeAlias(c *Context, invoke []string) error {
+	if len(invoke) == 0 {
+		return nil
+	}
+	parent := c.Parent()
+	cmd, ok := parent.Command().Command(invoke[0])
+	if !ok {
+		return commandMissing(invoke[0])
+	}
+	if _, isAlias := cmd.Data[privatekey.CommandAlias]; isAlias {
+		return fmt.Errorf("alias %q cannot refer to another alias %q", c.Name(), invoke[0])
+	}
+	return parent.newChild(cmd, ActionTiming).Execute(invoke)
+}
+
+// RegisterCommand registers a command to be added to apps that use MountRegistered.
+// This allows packages to contribute sub-commands without the package defining the app
+// importing them explicitly.  Typically, RegisterCommand is called from the init function
+// of the contributing package.  The path names the parent command as the names of each
+// sub-command from the root, separated by spaces.  The empty string indicates the root
+// command.  For example, "remote" adds the command as a sub-command of the "remote" command.
+// The function is called to create the command each time it is mounted, so that apps
+// and repeated runs don't share the command, its flags, or their values.  A panic occurs
+// if the path contains names which are not command names, such as flags or args.
+func RegisterCommand(path string, fn func() *Command) {
+	register(path, registration{cmd: fn})
+}
+
+// RegisterFlag registers a flag to be added to apps that use MountRegistered.  The path
+// names the command which contains the flag.  See RegisterCommand for details.
+func RegisterFlag(path string, fn func() *Flag) {
+	register(path, registration{flag: fn})
+}
+
+// MountRegistered provides an action which adds the commands and flags registered with
+// RegisterCommand and RegisterFlag to the commands at the corresponding paths.  It is
+// typically used in the Uses pipeline of the App.  If a command or flag with the same name
+// already exists at the path, an InternalError is returned.  Registrations whose path does not
+// name a command in the app are not mounted.
+func MountRegistered() Action {
+	return ActionFunc(func(c *Context) error {
+		globals.RLock()
+		registry := slices.Clone(globals.registry)
+		globals.RUnlock()
+
+		for _, r := range registry {
+			if len(r.path) == 0 {
+				if err := r.mount(c); err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := c.Customize(r.path.String(), ActionFunc(func(c *Context) error {
+				if !slices.Equal(commandPathFromRoot(c), r.path) {
+					return nil
+				}
+				return r.mount(c)
+			}))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type registration struct {
+	path ContextPath
+	cmd  func() *Command
+	flag func() *Flag
+}
+
+func register(path string, r registration) {
+	r.path = ContextPath(strings.Fields(path))
+	for _, name := range r.path {
+		if !matchCommand(name) || name == "*" {
+			panic(fmt.Sprintf("invalid registration path %q: %q is not a command name", path, name))
+		}
+	}
+
+	globals.Lock()
+	defer globals.Unlock()
+	globals.registry = append(globals.registry, r)
+}
+
+func (r registration) mount(c *Context) error {
+	if r.cmd != nil {
+		cmd := r.cmd()
+		if _, exists := c.Command().Command(cmd.Name); exists {
+			return c.internalError(fmt.Errorf("registered command %q conflicts with existing command", cmd.Name))
+		}
+		return c.AddCommand(cmd)
+	}
+
+	flag := r.flag()
+	if _, exists := c.Command().Flag(flag.Name); exists {
+		return c.internalError(fmt.Errorf("registered flag %q conflicts with existing flag", flag.Name))
+	}
+	return c.AddFlag(flag)
+}
+
+// commandPathFromRoot gets the names of the commands from the root, excluding the root
+func commandPathFromRoot(c *Context) ContextPath {
+	res := make([]string, 0)
+	for _, ctx := range c.Lineage() {
+		if ctx.Parent() != nil {
+			res = append(res, ctx.Name())
+		}
+	}
+	slices.Reverse(res)
+	return ContextPath(res)
+}
+
 // SuggestCommand provides a CommandNotFoundHandler which suggests sub-commands
 // that are similar to the one that could not be found.  When suggestions are found, they are
 // recorded on the ParseError via its Suggestions attribute and rendered using
@@ -277,7 +420,7 @@ func SuggestCommand() CommandNotFoundHandler {
 			return nil, err
 		}
 
-		suggestions := suggestCommandNames(pe.Name, commandSuggestionNames(c.Command()))
+		suggestions := SuggestNames(pe.Name, commandSuggestionNames(c.Command()))
 		if len(suggestions) == 0 {
 			return nil, err
 		}
@@ -306,9 +449,10 @@ func commandSuggestionNames(cmd *Command) []string {
 	return names
 }
 
-// suggestCommandNames returns the candidates which are similar to name, ordered
-// from most to least similar (ties broken alphabetically).
-func suggestCommandNames(name string, candidates []string) []string {
+// SuggestNames returns the candidates which are similar to name, ordered
+// from most to least similar (ties broken alphabetically).  This is how commands
+// are suggested when a command is not found (see SuggestCommand).
+func SuggestNames(name string, candidates []string) []string {
 	if name == "" {
 		return nil
 	}
@@ -1044,6 +1044,184 @@ var _ = Describe("ImplicitCommand", func() {
 	})
 })
 
+var _ = Describe("AliasCommand", func() {
+
+	var newApp = func(aliases ...*cli.Command) (*cli.App, *joeclifakes.FakeAction) {
+		act := new(joeclifakes.FakeAction)
+		return &cli.App{
+			Commands: append([]*cli.Command{
+				{
+					Name: "checkout",
+					Flags: []*cli.Flag{
+						{Name: "b", Value: new(bool)},
+					},
+					Args: []*cli.Arg{
+						{Name: "branch", Value: new(string)},
+					},
+					Action: act,
+				},
+			}, aliases...),
+		}, act
+	}
+
+	It("invokes the target with expanded arguments", func() {
+		app, act := newApp(cli.AliasCommand("co", "checkout", "-b"))
+
+		args, _ := cli.Split("app co topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.Args()).To(Equal([]string{"checkout", "-b", "topic"}))
+		Expect(captured.Bool("b")).To(BeTrue())
+		Expect(captured.String("branch")).To(Equal("topic"))
+	})
+
+	It("can be returned from a command not found handler", func() {
+		app, act := newApp()
+		app.Uses = cli.HandleCommandNotFound(func(_ *cli.Context, err error) (*cli.Command, error) {
+			if err.(*cli.ParseError).Name == "co" {
+				return cli.AliasCommand("co", "checkout"), nil
+			}
+			return nil, err
+		})
+
+		args, _ := cli.Split("app co topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+	})
+
+	It("sets up help text", func() {
+		cmd := cli.AliasCommand("co", "checkout", "-b")
+		Expect(cmd.HelpText).To(Equal("Alias for checkout -b"))
+	})
+
+	DescribeTable("errors", func(alias *cli.Command, expected string) {
+		app, _ := newApp(alias, cli.AliasCommand("c", "checkout"))
+
+		args, _ := cli.Split("app " + alias.Name)
+		err := app.RunContext(context.Background(), args)
+		Expect(err).To(MatchError(expected))
+	},
+		Entry("missing target", cli.AliasCommand("st", "status"), `"status" is not a command`),
+		Entry("alias of alias", cli.AliasCommand("x", "c"), `alias "x" cannot refer to another alias "c"`),
+	)
+})
+
+var _ = Describe("RegisterCommand", func() {
+
+	AfterEach(func() {
+		cli.ResetRegistry()
+	})
+
+	It("mounts registered commands at the path", func() {
+		act := new(joeclifakes.FakeAction)
+		cli.RegisterCommand("", func() *cli.Command { return &cli.Command{Name: "top"} })
+		cli.RegisterCommand("remote", func() *cli.Command { return &cli.Command{Name: "prune", Action: act} })
+		cli.RegisterFlag("remote", func() *cli.Flag { return &cli.Flag{Name: "verbose", Value: new(bool)} })
+
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.MountRegistered(),
+			Commands: []*cli.Command{
+				{Name: "remote"},
+			},
+		}
+
+		args, _ := cli.Split("app remote --verbose prune")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+
+		_, ok := app.Command("top")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("mounts only at the path from the root", func() {
+		cli.RegisterCommand("remote", func() *cli.Command { return &cli.Command{Name: "prune"} })
+
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.MountRegistered(),
+			Commands: []*cli.Command{
+				{
+					Name: "other",
+					Subcommands: []*cli.Command{
+						{Name: "remote"},
+					},
+				},
+			},
+		}
+
+		_, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		remote := app.Commands[0].Subcommands[0]
+		_, ok := remote.Command("prune")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("creates the flags for each app", func() {
+		cli.RegisterFlag("", func() *cli.Flag { return &cli.Flag{Name: "verbose", Value: new(bool)} })
+
+		run := func(arguments string) bool {
+			var verbose bool
+			app := &cli.App{
+				Name: "app",
+				Uses: cli.MountRegistered(),
+				Action: func(c *cli.Context) {
+					verbose = c.Bool("verbose")
+				},
+			}
+			args, _ := cli.Split(arguments)
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			return verbose
+		}
+
+		Expect(run("app --verbose")).To(BeTrue())
+		Expect(run("app")).To(BeFalse())
+	})
+
+	DescribeTable("invalid paths", func(path string) {
+		Expect(func() {
+			cli.RegisterCommand(path, func() *cli.Command { return &cli.Command{Name: "x"} })
+		}).To(PanicWith(ContainSubstring("is not a command name")))
+	},
+		Entry("flag", "remote --verbose"),
+		Entry("arg", "remote <name>"),
+		Entry("wildcard", "*"),
+	)
+
+	DescribeTable("conflicts", func(register func(), expected string) {
+		register()
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.MountRegistered(),
+			Commands: []*cli.Command{
+				{Name: "remote"},
+			},
+		}
+
+		_, err := app.Initialize(context.Background())
+		Expect(err).To(BeAssignableToTypeOf(&cli.InternalError{}))
+		Expect(err).To(MatchError(ContainSubstring(expected)))
+	},
+		Entry("existing command", func() {
+			cli.RegisterCommand("", func() *cli.Command { return &cli.Command{Name: "remote"} })
+		}, `registered command "remote" conflicts with existing command`),
+		Entry("duplicate registration", func() {
+			cli.RegisterCommand("remote", func() *cli.Command { return &cli.Command{Name: "prune"} })
+			cli.RegisterCommand("remote", func() *cli.Command { return &cli.Command{Name: "prune"} })
+		}, `registered command "prune" conflicts with existing command`),
+		Entry("existing flag", func() {
+			cli.RegisterFlag("", func() *cli.Flag { return &cli.Flag{Name: "a"} })
+			cli.RegisterFlag("", func() *cli.Flag { return &cli.Flag{Name: "a"} })
+		}, `registered flag "a" conflicts with existing flag`),
+	)
+})
+
 var _ = Describe("SuggestCommand", func() {
 
 	var newApp = func(opts cli.Option) (*cli.App, *bytes.Buffer) {
@@ -106,10 +106,21 @@ func (c *Context) clearCompletionRequest() {
 	c.SetData(completionRequestKey, nil)
 }
 
+// CompletionEnvVar gets the name of the environment variable which requests
+// dynamic shell completion from the app with the given name.  The value of the
+// environment variable is the name of the shell, such as zsh.
+func CompletionEnvVar(appName string) string {
+	return fmt.Sprintf("_JOE_%s_COMPLETE", strings.ToUpper(completionSlug(appName)))
+}
+
+func completionSlug(appName string) string {
+	return strings.ReplaceAll(shellUnsafeChars.ReplaceAllString(appName, ""), "-", "_")
+}
+
 func newCompletionData(c *Context) *completionData {
 	appName := c.App().Name
-	slug := strings.ReplaceAll(shellUnsafeChars.ReplaceAllString(appName, ""), "-", "_")
-	envVar := fmt.Sprintf("_JOE_%s_COMPLETE", strings.ToUpper(slug))
+	slug := completionSlug(appName)
+	envVar := CompletionEnvVar(appName)
 	shell := os.Getenv(envVar)
 
 	return &completionData{
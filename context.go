@@ -15,6 +15,7 @@ import (
 	"iter"
 	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"regexp"
@@ -270,7 +271,19 @@ func (c *Context) Execute(args []string) error {
 
 func (c *Context) parse(args []string) *robustParseResult {
 	root := c.Command()
+	if root.internalFlags().responseFiles() {
+		expanded, err := expandResponseFiles(c.actualFS(), args)
+		if err != nil {
+			return &robustParseResult{bindings: newBindingResult(args), err: err}
+		}
+		args = expanded
+	}
+
 	set := root.buildSet(c)
+	if c.Parent() != nil {
+		// Positions are relative to the args of the root command
+		set.offset = len(c.Root().Args()) - len(args)
+	}
 	flags := root.internalFlags().toRaw() | RawSkipProgramName
 	err := set.parse(args, flags)
 	return &robustParseResult{bindings: set.BindingResult, err: err}
@@ -767,12 +780,33 @@ func (c *Context) Occurrences(name any) int {
 	return -1
 }
 
+// ValueSource gets where the value of the flag or arg was obtained, which is
+// the command line, an environment variable, a file, a value implied by another
+// flag, or configuration.  When the value wasn't set from any of these, the
+// result has kind DefaultSource.
+func (c *Context) ValueSource(name any) ValueSource {
+	if o, ok := c.lookupOption(name); ok {
+		if s := o.valueSource(); s != nil {
+			return *s
+		}
+	}
+	return ValueSource{}
+}
+
 // ImplicitlySet returns true if the flag or arg was implicitly
 // set.
 func (c *Context) ImplicitlySet() bool {
 	return c.target().internalFlags().seenImplied()
 }
 
+// ImplicitlySetFrom returns true if the flag or arg was implicitly
+// set from one of the given kinds of sources.  For example, this can be used
+// to determine whether the value was obtained from configuration (ConfigSource)
+// rather than an environment variable.
+func (c *Context) ImplicitlySetFrom(kinds ...ValueSourceKind) bool {
+	return c.ImplicitlySet() && slices.Contains(kinds, c.ValueSource("").Kind)
+}
+
 // NValue gets the maximum number available, exclusive, as an argument Value.
 func (c *Context) NValue() int {
 	return len(c.LocalArgs())
@@ -883,6 +917,19 @@ func (c *Context) LookupData(name string) (any, bool) {
 	return c.Parent().LookupData(name)
 }
 
+// LookupEnv looks up an environment variable using the function set by SetLookupEnv
+// in the lineage, or the process environment
+func (c *Context) LookupEnv(name string) (string, bool) {
+	if v, ok := c.LookupData(lookupEnvDataKey); ok {
+		if fn, ok := v.(func(string) (string, bool)); ok {
+			if res, ok := fn(name); ok {
+				return res, true
+			}
+		}
+	}
+	return os.LookupEnv(name)
+}
+
 // Aliases obtains the aliases for the current command or flag; otherwise,
 // for args it is nill
 func (c *Context) Aliases() []string {
@@ -1099,6 +1146,18 @@ func (c *Context) SetValue(arg any) error {
 	return c.target().(option).Set(arg)
 }
 
+// SetValueFrom sets the value of the current flag or arg using the same
+// semantics as SetValue.  When successful, the source is recorded so that
+// it can be obtained from ValueSource.  This is typically used by actions
+// that provide implicit values.
+func (c *Context) SetValueFrom(source ValueSource, arg any) error {
+	if err := c.SetValue(arg); err != nil {
+		return err
+	}
+	c.option().setValueSource(&source)
+	return nil
+}
+
 // At either stores or executes the action at the given timing.
 func (c *Context) At(t Timing, v Action) error {
 	return Do(c, At(t, v))
@@ -2093,6 +2152,21 @@ func (c *Context) Duration(name any) time.Duration {
 	return lookupDuration(c, name)
 }
 
+// Time obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) Time(name any) time.Time {
+	return lookupTime(c, name)
+}
+
+// SemVer obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) SemVer(name any) Version {
+	return lookupSemVer(c, name)
+}
+
+// SemVerConstraint obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) SemVerConstraint(name any) VersionConstraint {
+	return lookupSemVerConstraint(c, name)
+}
+
 // File obtains a value by the name of the flag, arg, or other value in scope
 func (c *Context) File(name any) *File {
 	return lookupFile(c, name)
@@ -2133,6 +2207,56 @@ func (c *Context) IP(name any) net.IP {
 	return lookupIP(c, name)
 }
 
+// IPNet obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) IPNet(name any) netip.Prefix {
+	return lookupIPNet(c, name)
+}
+
+// Addr obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) Addr(name any) netip.Addr {
+	return lookupAddr(c, name)
+}
+
+// AddrPort obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) AddrPort(name any) netip.AddrPort {
+	return lookupAddrPort(c, name)
+}
+
+// HostPort obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) HostPort(name any) string {
+	return lookupHostPort(c, name)
+}
+
+// HardwareAddr obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) HardwareAddr(name any) net.HardwareAddr {
+	return lookupHardwareAddr(c, name)
+}
+
+// IPRange obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) IPRange(name any) *IPRangeValue {
+	return lookupIPRange(c, name)
+}
+
+// ByteSize obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) ByteSize(name any) int64 {
+	return lookupByteSize(c, name)
+}
+
+// Percent obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) Percent(name any) float64 {
+	return lookupPercent(c, name)
+}
+
+// SI obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) SI(name any) float64 {
+	return lookupSI(c, name)
+}
+
+// Rate obtains a value by the name of the flag, arg, or other value in scope
+func (c *Context) Rate(name any) *Rate {
+	return lookupRate(c, name)
+}
+
 // BigInt obtains a value by the name of the flag, arg, or other value in scope
 func (c *Context) BigInt(name any) *big.Int {
 	return lookupBigInt(c, name)
@@ -0,0 +1,216 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"encoding"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// TypedEnum is a value which is one of a fixed set of named values.  Names are
+// matched without regard to case.  An unambiguous prefix of a name is also
+// accepted, so "js" selects the name "json" unless another name also starts
+// with "js".
+//
+// When HelpText is provided for names, the help text is shown in the extended
+// description of the flag or arg on the help screen and as the description of
+// completion items.
+type TypedEnum[T comparable] struct {
+	// Values maps each name to its value
+	Values map[string]T
+
+	// HelpText maps names to the help text which describes them
+	HelpText map[string]string
+
+	name  string
+	value T
+}
+
+type enumDescription struct {
+	option   string
+	names    []string
+	helpText map[string]string
+}
+
+// EnumValue creates an enum value from the names and their corresponding values
+func EnumValue[T comparable](values map[string]T) *TypedEnum[T] {
+	return &TypedEnum[T]{
+		Values:   values,
+		HelpText: map[string]string{},
+	}
+}
+
+// EnumFromValues creates an enum value from the values.  The name of each value is
+// obtained from its MarshalText method if it implements encoding.TextMarshaler;
+// otherwise, its name is obtained from fmt.Sprint.  This is useful for constants
+// which already provide their names.
+func EnumFromValues[T comparable](values ...T) *TypedEnum[T] {
+	m := make(map[string]T, len(values))
+	for _, v := range values {
+		m[enumName(v)] = v
+	}
+	return EnumValue(m)
+}
+
+// Set will set the value to the value which has the matching name
+func (e *TypedEnum[T]) Set(arg string) error {
+	name, err := e.match(arg)
+	if err != nil {
+		return err
+	}
+	e.name = name
+	e.value = e.Values[name]
+	return nil
+}
+
+// String obtains the name of the value
+func (e *TypedEnum[T]) String() string {
+	if e.name != "" {
+		return e.name
+	}
+	var zero T
+	if e.value == zero {
+		return ""
+	}
+	for _, name := range e.Names() {
+		if e.Values[name] == e.value {
+			return name
+		}
+	}
+	return fmt.Sprint(e.value)
+}
+
+// Value obtains the value
+func (e *TypedEnum[T]) Value() T {
+	return e.value
+}
+
+// Names obtains the names of the values in sorted order
+func (e *TypedEnum[T]) Names() []string {
+	return slices.Sorted(maps.Keys(e.Values))
+}
+
+// EnumNames obtains the names of the values in sorted order.  This is the same
+// as Names and is used by other packages to detect enum values.
+func (e *TypedEnum[T]) EnumNames() []string {
+	return e.Names()
+}
+
+// Reset sets the value back to the zero value
+func (e *TypedEnum[T]) Reset() {
+	var zero T
+	e.name = ""
+	e.value = zero
+}
+
+// Copy creates a copy of the enum value
+func (e *TypedEnum[T]) Copy() *TypedEnum[T] {
+	res := *e
+	return &res
+}
+
+// Synopsis obtains the synopsis text, which lists the names
+func (e *TypedEnum[T]) Synopsis() string {
+	names := e.Names()
+	if len(names) > 3 {
+		return "(" + strings.Join(names[0:3], "|") + "|...)"
+	}
+	return "(" + strings.Join(names, "|") + ")"
+}
+
+// Completion obtains the completion for the names, which includes their help text
+func (e *TypedEnum[T]) Completion() Completion {
+	return CompletionFunc(func(c *Context) []CompletionItem {
+		return filterCompletionOnContext(c, func(yield func(CompletionItem) bool) {
+			for _, name := range e.Names() {
+				item := CompletionItem{
+					Type:     CompletionTypeToken,
+					Value:    name,
+					HelpText: e.HelpText[name],
+				}
+				if !yield(item) {
+					return
+				}
+			}
+		})
+	})
+}
+
+// Initializer obtains the initializer for the enum value, which sets up the
+// description of the flag or arg to list help text for the names
+func (e *TypedEnum[T]) Initializer() Action {
+	return ActionFunc(func(c *Context) error {
+		if len(e.HelpText) == 0 {
+			return nil
+		}
+		return c.Do(&Prototype{
+			Description: &enumDescription{
+				option:   c.Name(),
+				names:    e.Names(),
+				helpText: e.HelpText,
+			},
+		})
+	})
+}
+
+func (e *TypedEnum[T]) match(arg string) (string, error) {
+	names := e.Names()
+	for _, name := range names {
+		if strings.EqualFold(name, arg) {
+			return name, nil
+		}
+	}
+
+	var candidates []string
+	if arg != "" {
+		lower := strings.ToLower(arg)
+		for _, name := range names {
+			if strings.HasPrefix(strings.ToLower(name), lower) {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("unrecognized value %q, expected %s", arg, listOfValues(names, true))
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("ambiguous value %q, could be %s", arg, listOfValues(candidates, true))
+	}
+}
+
+func (e *TypedEnum[T]) setDirect(v any) error {
+	e.name = ""
+	e.value = v.(T)
+	return nil
+}
+
+func (d *enumDescription) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Values for %s:\n", d.option)
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for _, name := range d.names {
+		fmt.Fprintf(w, "    %s\t%s\n", name, d.helpText[name])
+	}
+	w.Flush()
+	return b.String()
+}
+
+func enumName(v any) string {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+var _ Value = (*TypedEnum[string])(nil)
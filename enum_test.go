@@ -0,0 +1,134 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type shape int
+
+const (
+	circle shape = iota + 1
+	square
+	triangle
+)
+
+func (s shape) MarshalText() ([]byte, error) {
+	return []byte([...]string{"", "circle", "square", "triangle"}[s]), nil
+}
+
+var _ = Describe("TypedEnum", func() {
+
+	newFormat := func() *cli.TypedEnum[int] {
+		e := cli.EnumValue(map[string]int{
+			"json":  1,
+			"jsonl": 2,
+			"text":  3,
+			"table": 4,
+		})
+		e.HelpText["json"] = "Format as JSON"
+		e.HelpText["text"] = "Format as plain text"
+		return e
+	}
+
+	DescribeTable("examples",
+		func(text string, expected int) {
+			e := newFormat()
+			Expect(e.Set(text)).To(Succeed())
+			Expect(e.Value()).To(Equal(expected))
+		},
+		Entry("exact", "text", 3),
+		Entry("case-insensitive", "TEXT", 3),
+		Entry("exact match preferred to prefix", "json", 1),
+		Entry("unique prefix", "tex", 3),
+		Entry("unique prefix case-insensitive", "TA", 4),
+	)
+
+	DescribeTable("errors",
+		func(text string, expected string) {
+			e := newFormat()
+			Expect(e.Set(text)).To(MatchError(expected))
+		},
+		Entry("unrecognized", "yaml", "unrecognized value \"yaml\", expected `json', `jsonl', `table', or `text'"),
+		Entry("ambiguous", "t", "ambiguous value \"t\", could be `table' or `text'"),
+	)
+
+	It("obtains names from constants", func() {
+		e := cli.EnumFromValues(circle, square, triangle)
+		Expect(e.Names()).To(Equal([]string{"circle", "square", "triangle"}))
+		Expect(e.Set("sq")).To(Succeed())
+		Expect(e.Value()).To(Equal(square))
+	})
+
+	It("obtains the value from the context", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "shape", Value: cli.EnumFromValues(circle, square, triangle)},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app --shape tri")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.Value("shape")).To(Equal(triangle))
+	})
+
+	It("formats the name as text", func() {
+		e := cli.EnumFromValues(circle, square)
+		Expect(e.String()).To(BeEmpty())
+
+		Expect(cli.Set(e, square)).To(Succeed())
+		Expect(e.String()).To(Equal("square"))
+	})
+
+	It("has synopsis", func() {
+		f := &cli.Flag{Name: "format", Value: newFormat()}
+		Expect(f.Synopsis()).To(Equal("--format=(json|jsonl|table|...)"))
+	})
+
+	It("displays help text for values in help", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "format", Value: newFormat()},
+			},
+		}
+
+		screen := renderScreen(app, "app --help")
+		Expect(screen).To(ContainSubstring("Values for --format:"))
+		Expect(screen).To(MatchRegexp(`json +Format as JSON`))
+		Expect(screen).To(MatchRegexp(`text +Format as plain text`))
+	})
+
+	It("provides completion with help text", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "format", Value: newFormat()},
+			},
+			Action: func() {},
+		}
+
+		args, _ := cli.Split("app --format")
+		ctx, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		items := cli.FromContext(ctx).Complete(args, "js")
+		Expect(items).To(Equal([]cli.CompletionItem{
+			{Value: "json", HelpText: "Format as JSON"},
+			{Value: "jsonl"},
+		}))
+	})
+})
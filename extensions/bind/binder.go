@@ -78,6 +78,7 @@ import (
 	"io"
 	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -522,6 +523,33 @@ func Duration(nameopt ...any) Binder[time.Duration] {
 	return byName((*cli.Context).Duration, nameopt)
 }
 
+// Time obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a time value (see cli.Time).
+func Time(nameopt ...any) Binder[time.Time] {
+	return withValue(byName((*cli.Context).Time, nameopt), func() any { return cli.Time() })
+}
+
+// SemVer obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a semantic version value (see cli.SemVer).
+func SemVer(nameopt ...any) Binder[cli.Version] {
+	return withValue(byName((*cli.Context).SemVer, nameopt), func() any { return cli.SemVer() })
+}
+
+// SemVerConstraint obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a semantic version constraint value (see cli.SemVerConstraint).
+func SemVerConstraint(nameopt ...any) Binder[cli.VersionConstraint] {
+	return withValue(byName((*cli.Context).SemVerConstraint, nameopt), func() any { return cli.SemVerConstraint() })
+}
+
 // File obtains a binder that obtains a value from the context. If the name is
 // not specified, then either the current flag or arg is used or the corresponding
 // argument by index.
@@ -594,6 +622,96 @@ func IP(nameopt ...any) Binder[net.IP] {
 	return byName((*cli.Context).IP, nameopt)
 }
 
+// IPNet obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with an IP network value (see cli.IPNet).
+func IPNet(nameopt ...any) Binder[netip.Prefix] {
+	return withValue(byName((*cli.Context).IPNet, nameopt), func() any { return cli.IPNet() })
+}
+
+// Addr obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with an IP address value (see cli.Addr).
+func Addr(nameopt ...any) Binder[netip.Addr] {
+	return withValue(byName((*cli.Context).Addr, nameopt), func() any { return cli.Addr() })
+}
+
+// AddrPort obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with an IP address and port value (see cli.AddrPort).
+func AddrPort(nameopt ...any) Binder[netip.AddrPort] {
+	return withValue(byName((*cli.Context).AddrPort, nameopt), func() any { return cli.AddrPort() })
+}
+
+// HostPort obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a host and port value that uses the default port (see cli.HostPort).
+func HostPort(defaultPort int, nameopt ...any) Binder[string] {
+	return withValue(byName((*cli.Context).HostPort, nameopt), func() any { return cli.HostPort(defaultPort) })
+}
+
+// HardwareAddr obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a hardware address value (see cli.HardwareAddr).
+func HardwareAddr(nameopt ...any) Binder[net.HardwareAddr] {
+	return withValue(byName((*cli.Context).HardwareAddr, nameopt), func() any { return cli.HardwareAddr() })
+}
+
+// IPRange obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with an IP range value (see cli.IPRange).
+func IPRange(nameopt ...any) Binder[*cli.IPRangeValue] {
+	return withValue(byName((*cli.Context).IPRange, nameopt), func() any { return cli.IPRange() })
+}
+
+// ByteSize obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a byte size value (see cli.ByteSize).
+func ByteSize(nameopt ...any) Binder[int64] {
+	return withValue(byName((*cli.Context).ByteSize, nameopt), func() any { return cli.ByteSize() })
+}
+
+// Percent obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a percentage value (see cli.Percent).
+func Percent(nameopt ...any) Binder[float64] {
+	return withValue(byName((*cli.Context).Percent, nameopt), func() any { return cli.Percent() })
+}
+
+// SI obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a number with an SI prefix value (see cli.SI).
+func SI(nameopt ...any) Binder[float64] {
+	return withValue(byName((*cli.Context).SI, nameopt), func() any { return cli.SI() })
+}
+
+// Rate obtains a binder that obtains a value from the context. If the name is
+// not specified, then either the current flag or arg is used or the corresponding
+// argument by index.
+// When present in the Uses pipeline, this also sets up the corresponding flag or
+// arg with a reasonable default of the same type.
+func Rate(nameopt ...any) Binder[*cli.Rate] {
+	return byName((*cli.Context).Rate, nameopt)
+}
+
 // BigInt obtains a binder that obtains a value from the context. If the name is
 // not specified, then either the current flag or arg is used or the corresponding
 // argument by index.
@@ -691,6 +809,21 @@ func (b *BoolBinder) Negated() Binder[bool] {
 	})
 }
 
+type valueBinder[T any] struct {
+	*binder[T]
+	newValue func() any
+}
+
+func withValue[T any](b *binder[T], newValue func() any) Binder[T] {
+	return &valueBinder[T]{b, newValue}
+}
+
+func (b *valueBinder[_]) Initializer() cli.Action {
+	return b.prototypeThunk(func() *cli.Prototype {
+		return &cli.Prototype{Value: b.newValue()}
+	})
+}
+
 // FileBinder provides a binder for [cli.File]
 type FileBinder struct {
 	binderSupportInterface[*cli.File]
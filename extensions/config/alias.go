@@ -0,0 +1,163 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/exec"
+)
+
+const (
+	aliasPrefix = "alias."
+
+	// AliasCategory is the category used for commands that are defined by
+	// user aliases in the configuration
+	AliasCategory = "User aliases"
+)
+
+// Aliases provides an action which defines sub-commands from aliases
+// in the configuration.  Each configuration value named alias.NAME defines a
+// command NAME.  The value is split into arguments using shell syntax and
+// the first argument names a sibling command to execute (see cli.AliasCommand).
+// If the value starts with "!", the remainder is run as a shell command line,
+// with any arguments passed to the alias available as its positional
+// parameters (see exec.Shell).  For example:
+//
+//	alias.co = checkout -b
+//	alias.root = !git rev-parse --show-toplevel
+//
+// The values of aliases are not interpolated (see WithLiteralKeys), so that shell
+// aliases can refer to their positional parameters such as $1.
+//
+// Aliases never replace commands that are already defined.  Aliases are resolved
+// when a command is not found rather than when the app is initialized, so the
+// configuration is loaded after flags such as --profile are parsed.  An alias whose
+// value is invalid is skipped with a warning.  When help or shell completion is
+// requested and the store can enumerate its keys (see KeyLister), the aliases are
+// added as commands in the category AliasCategory so that they are listed.
+func Aliases() cli.Action {
+	return cli.Pipeline(
+		cli.HandleCommandNotFound(findAliasCommand),
+		cli.At(cli.ImplicitValueTiming, cli.ActionFunc(listAliasCommands)),
+	)
+}
+
+// listAliasCommands adds the aliases to the command so that they are listed in
+// help and completion.  This happens only when help or completion is requested so
+// that the aliases aren't added to the command on every invocation.
+func listAliasCommands(c *cli.Context) error {
+	if !helpOrCompletionRequested(c) {
+		return nil
+	}
+	cfg, ok := aliasConfig(c)
+	if !ok {
+		return nil
+	}
+
+	cmd := c.Command()
+	store := cfg.Store()
+	for key := range Keys(store) {
+		name, ok := strings.CutPrefix(key, aliasPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		if _, exists := cmd.Command(name); exists {
+			continue
+		}
+
+		// Invalid aliases are reported when they are used
+		alias, err := newAliasCommand(name, store.String(key))
+		if err != nil {
+			continue
+		}
+		cmd.Subcommands = append(cmd.Subcommands, alias)
+	}
+	return nil
+}
+
+func helpOrCompletionRequested(c *cli.Context) bool {
+	if c.Seen("help") {
+		return true
+	}
+	if args := c.List("command"); len(args) > 0 && args[0] == "help" {
+		return true
+	}
+	return os.Getenv(cli.CompletionEnvVar(c.App().Name)) != ""
+}
+
+// aliasConfig gets the config from the context and ensures that the values of
+// aliases are not interpolated
+func aliasConfig(c *cli.Context) (*Config, bool) {
+	cfg, err := tryFromContext[*Config](c)
+	if err != nil {
+		return nil, false
+	}
+	cfg.addLiteralKeys(aliasPrefix + "*")
+	return cfg, true
+}
+
+func findAliasCommand(c *cli.Context, err error) (*cli.Command, error) {
+	var pe *cli.ParseError
+	if !errors.As(err, &pe) || pe.Name == "" {
+		return nil, err
+	}
+	cfg, ok := aliasConfig(c)
+	if !ok {
+		return nil, err
+	}
+
+	key := aliasPrefix + pe.Name
+	if !cfg.Has(key) {
+		return nil, err
+	}
+	cmd, aliasErr := newAliasCommand(pe.Name, cfg.String(key))
+	if aliasErr != nil {
+		fmt.Fprintf(c.Stderr, "warning: skipping invalid alias: %v\n", aliasErr)
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func newAliasCommand(name string, value string) (*cli.Command, error) {
+	if line, ok := strings.CutPrefix(value, "!"); ok {
+		return &cli.Command{
+			Name:     name,
+			HelpText: "Alias for shell command " + line,
+			Category: AliasCategory,
+			Options:  cli.SkipFlagParsing,
+			Args: []*cli.Arg{
+				{
+					Name:    "args",
+					Value:   cli.List(),
+					NArg:    -1,
+					Options: cli.DisableSplitting,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return c.Do(&exec.Shell{
+					Command: line,
+					Args:    c.List("args"),
+				})
+			},
+		}, nil
+	}
+
+	args, err := cli.Split(value)
+	if err != nil {
+		return nil, fmt.Errorf("alias %q: %w", name, err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("alias %q: expected command", name)
+	}
+
+	cmd := cli.AliasCommand(name, args...)
+	cmd.Category = AliasCategory
+	return cmd, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	"github.com/Carbonfrost/joe-cli/extensions/config/configfakes"
+	"github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aliases", func() {
+
+	var newApp = func(store config.Store) (*cli.App, *joeclifakes.FakeAction) {
+		act := new(joeclifakes.FakeAction)
+		return &cli.App{
+			Name: "app",
+			Uses: cli.Pipeline(
+				config.New(config.WithStore(store)),
+				config.Aliases(),
+			),
+			Commands: []*cli.Command{
+				{
+					Name: "checkout",
+					Flags: []*cli.Flag{
+						{Name: "b", Value: new(bool)},
+					},
+					Args: []*cli.Arg{
+						{Name: "branch", Value: new(string)},
+					},
+					Action: act,
+				},
+			},
+		}, act
+	}
+
+	It("invokes the command named by the alias", func() {
+		app, act := newApp(config.Values{"alias.co": "checkout -b"})
+
+		args, _ := cli.Split("app co topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.Bool("b")).To(BeTrue())
+		Expect(captured.String("branch")).To(Equal("topic"))
+	})
+
+	It("resolves aliases when the store cannot enumerate keys", func() {
+		values := cli.LookupValues{"alias.co": "checkout"}
+		app, act := newApp(config.NewStore(values, func(k string) bool {
+			_, ok := values[k]
+			return ok
+		}))
+
+		args, _ := cli.Split("app co topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+	})
+
+	It("does not load the store when the app is initialized", func() {
+		loader := new(configfakes.FakeLoader)
+		loader.LoadReturns(config.Values{"alias.co": "checkout -b"}, nil)
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.Pipeline(
+				config.New(config.WithLoader(loader)),
+				config.Aliases(),
+			),
+		}
+
+		_, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loader.LoadCallCount()).To(Equal(0))
+	})
+
+	DescribeTable("lists aliases in help", func(arguments string) {
+		var stderr bytes.Buffer
+		app, _ := newApp(config.Values{"alias.co": "checkout -b", "alias.hi": "!echo hello"})
+		app.Stderr = &stderr
+
+		args, _ := cli.Split(arguments)
+		_ = app.RunContext(context.Background(), args)
+		Expect(stderr.String()).To(ContainSubstring(config.AliasCategory + ":\n"))
+		Expect(stderr.String()).To(MatchRegexp(`\n +co +Alias for checkout -b\n`))
+		Expect(stderr.String()).To(MatchRegexp(`\n +hi +Alias for shell command echo hello`))
+	},
+		Entry("help flag", "app --help"),
+		Entry("help command", "app help"),
+	)
+
+	It("lists aliases in completion", func() {
+		var stdout bytes.Buffer
+		app, _ := newApp(config.Values{"alias.co": "checkout -b"})
+		app.Stdout = &stdout
+		GinkgoT().Setenv(cli.CompletionEnvVar("app"), "zsh")
+		GinkgoT().Setenv("COMP_WORDS", "app c")
+		GinkgoT().Setenv("COMP_CWORD", "1")
+
+		_ = app.RunContext(context.Background(), []string{"app"})
+		Expect(stdout.String()).To(ContainSubstring("\ncheckout\n"))
+		Expect(stdout.String()).To(ContainSubstring("\nco\nAlias for checkout -b\n"))
+	})
+
+	It("does not replace existing commands", func() {
+		app, act := newApp(config.Values{"alias.checkout": "status"})
+
+		args, _ := cli.Split("app checkout topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+	})
+
+	It("runs shell aliases", func() {
+		if runtime.GOOS == "windows" {
+			Skip("not tested on Windows")
+		}
+
+		var out bytes.Buffer
+		app, _ := newApp(config.Values{"alias.hi": "!echo hello"})
+		app.Stdout = &out
+
+		args, _ := cli.Split("app hi world")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal("hello world\n"))
+	})
+
+	It("does not interpolate shell aliases", func() {
+		if runtime.GOOS == "windows" {
+			Skip("not tested on Windows")
+		}
+
+		var out bytes.Buffer
+		app, _ := newApp(config.Values{"alias.hi": `!echo "hello [$1]"`})
+		app.Stdout = &out
+
+		args, _ := cli.Split("app hi world")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal("hello [world] world\n"))
+	})
+
+	It("skips an invalid alias with a warning", func() {
+		var stderr bytes.Buffer
+		app, _ := newApp(config.Values{"alias.co": "checkout 'unterminated"})
+		app.Stderr = &stderr
+
+		args, _ := cli.Split("app co")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).To(HaveOccurred())
+		Expect(stderr.String()).To(ContainSubstring(`warning: skipping invalid alias: alias "co"`))
+	})
+
+	It("runs other commands when an alias is invalid", func() {
+		app, act := newApp(config.Values{"alias.co": "checkout 'unterminated"})
+
+		args, _ := cli.Split("app checkout topic")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.ExecuteCallCount()).To(Equal(1))
+	})
+})
+
+var _ = Describe("Keys", func() {
+
+	It("enumerates values in sorted order", func() {
+		store, _ := config.FromValues(
+			config.Value{Name: "b", Value: "2"},
+			config.Value{Name: "a", Value: "1"},
+		)
+		Expect(config.Keys(store)).To(HaveExactElements("a", "b"))
+	})
+
+	It("is empty when store does not support enumeration", func() {
+		store := config.NewStore(cli.LookupValues{"a": "1"}, func(string) bool { return true })
+		Expect(config.Keys(store)).To(BeEmpty())
+	})
+})
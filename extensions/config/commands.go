@@ -0,0 +1,349 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/exec"
+)
+
+// layerFlagNames maps the names of the flags which select a layer
+var layerFlagNames = []struct {
+	name  string
+	layer Layer
+}{
+	{"system", LayerSystem},
+	{"user", LayerUser},
+	{"workspace", LayerWorkspace},
+}
+
+// Commands provides an action which adds the config command, which has
+// sub-commands to read and write the configuration in a manner similar to
+// git config:
+//
+//	config get KEY                 print a value
+//	config set KEY VALUE           set a value
+//	config unset KEY               remove a value
+//	config list [--show-origin]    list values, optionally with the file of each
+//	config edit                    open the file in the editor (see exec.Editor)
+//	config profiles                list the profiles and the profiles each extends
+//	config schema                  print the documentation of each key (see WithSchema)
+//	config secret set KEY [VALUE]  set a value in the secret file (see WithSecretFile)
+//	config secret get KEY          print a value from the secret file
+//	config secret rm KEY           remove a value from the secret file
+//
+// The flags --system, --user, and --workspace select the layer whose file is
+// read or written (see LayerLocation).  When no layer is selected, get and list
+// use the values from all layers, and the other commands write to the workspace
+// layer if there is a workspace, otherwise the user layer.  The types of numbers and
+// booleans in the file are preserved when a value is set.  When VALUE is omitted from
+// config secret set, it is prompted so that it isn't recorded in the shell history.
+// Secret values are redacted by config list.
+func Commands() cli.Action {
+	return cli.AddCommand(&cli.Command{
+		Name:     "config",
+		HelpText: "Get and set configuration values",
+		Subcommands: []*cli.Command{
+			{
+				Name:     "get",
+				HelpText: "Print the value of {KEY}",
+				Flags:    layerFlags(),
+				Args: []*cli.Arg{
+					{Name: "key", HelpText: "The qualified name of the value"},
+				},
+				Action: configGet,
+			},
+			{
+				Name:     "set",
+				HelpText: "Set {KEY} to {VALUE}",
+				Flags:    layerFlags(),
+				Args: []*cli.Arg{
+					{Name: "key", HelpText: "The qualified name of the value"},
+					{Name: "value", HelpText: "The value to set"},
+				},
+				Action: configSet,
+			},
+			{
+				Name:     "unset",
+				HelpText: "Remove the value of {KEY}",
+				Flags:    layerFlags(),
+				Args: []*cli.Arg{
+					{Name: "key", HelpText: "The qualified name of the value"},
+				},
+				Action: configUnset,
+			},
+			{
+				Name:     "list",
+				HelpText: "List the configuration values",
+				Flags: append(layerFlags(), &cli.Flag{
+					Name:     "show-origin",
+					HelpText: "Show the file which provides each value",
+					Value:    new(bool),
+				}),
+				Action: configList,
+			},
+			{
+				Name:     "edit",
+				HelpText: "Open the configuration file in the editor",
+				Flags:    layerFlags(),
+				Action:   configEdit,
+			},
+			{
+				Name:     "profiles",
+				HelpText: "List the profiles and the order in which their values are merged",
+				Action:   configProfiles,
+			},
+			{
+				Name:     "schema",
+				HelpText: "Print the documentation of each configuration key",
+				Action:   configSchema,
+			},
+			{
+				Name:     "secret",
+				HelpText: "Manage the values in the encrypted secret file",
+				Subcommands: []*cli.Command{
+					{
+						Name:     "set",
+						HelpText: "Set {KEY} to {VALUE} in the secret file",
+						Args: []*cli.Arg{
+							{Name: "key", HelpText: "The qualified name of the value"},
+							{
+								Name:     "value",
+								HelpText: "The value to set, which is prompted when it is omitted",
+								NArg:     cli.OptionalArg(nil),
+							},
+						},
+						Action: configSecretSet,
+					},
+					{
+						Name:     "get",
+						HelpText: "Print the value of {KEY} from the secret file",
+						Args: []*cli.Arg{
+							{Name: "key", HelpText: "The qualified name of the value"},
+						},
+						Action: configSecretGet,
+					},
+					{
+						Name:     "rm",
+						HelpText: "Remove the value of {KEY} from the secret file",
+						Args: []*cli.Arg{
+							{Name: "key", HelpText: "The qualified name of the value"},
+						},
+						Action: configSecretRm,
+					},
+				},
+			},
+		},
+	})
+}
+
+func layerFlags() []*cli.Flag {
+	names := make([]string, len(layerFlagNames))
+	for i, f := range layerFlagNames {
+		names[i] = f.name
+	}
+
+	res := make([]*cli.Flag, len(layerFlagNames))
+	for i, f := range layerFlagNames {
+		res[i] = &cli.Flag{
+			Name:     f.name,
+			HelpText: fmt.Sprintf("Use the %s configuration file", f.name),
+			Value:    new(bool),
+			Uses:     cli.Mutex(slices.DeleteFunc(slices.Clone(names), func(s string) bool { return s == f.name })...),
+		}
+	}
+	return res
+}
+
+func selectedLayer(c *cli.Context) Layer {
+	for _, f := range layerFlagNames {
+		if c.Bool(f.name) {
+			return f.layer
+		}
+	}
+	return LayerUnspecified
+}
+
+// writeLayer gets the layer which is written
+func writeLayer(c *cli.Context, cfg *Config) (Layer, error) {
+	if l := selectedLayer(c); l != LayerUnspecified {
+		return l, nil
+	}
+	for _, l := range []Layer{LayerWorkspace, LayerUser} {
+		if _, ok := cfg.LayerFile(c, l); ok {
+			return l, nil
+		}
+	}
+	return LayerUnspecified, fmt.Errorf("no configuration file to write")
+}
+
+func configGet(c *cli.Context) error {
+	cfg := FromContext(c)
+	key := c.String("key")
+
+	var store Store = cfg.Store()
+	if l := selectedLayer(c); l != LayerUnspecified {
+		values, err := cfg.LayerValues(c, l)
+		if err != nil {
+			return err
+		}
+		store = values
+	}
+	if !store.Has(key) {
+		return fmt.Errorf("no value for %s", key)
+	}
+	value := store.String(key)
+	if cfg.IsSecret(key) {
+		value = cli.Redacted
+	}
+	fmt.Fprintln(c.Stdout, value)
+	return nil
+}
+
+func configSet(c *cli.Context) error {
+	cfg := FromContext(c)
+	l, err := writeLayer(c, cfg)
+	if err != nil {
+		return err
+	}
+	return cfg.SetLayerValue(c, l, c.String("key"), c.String("value"))
+}
+
+func configUnset(c *cli.Context) error {
+	cfg := FromContext(c)
+	l, err := writeLayer(c, cfg)
+	if err != nil {
+		return err
+	}
+	return cfg.UnsetLayerValue(c, l, c.String("key"))
+}
+
+func configList(c *cli.Context) error {
+	cfg := FromContext(c)
+
+	var store Store = cfg.Store()
+	origin := func(key string) string {
+		f, ok := store.(LayerFinder)
+		if !ok {
+			return ""
+		}
+		l, ok := f.Layer(key)
+		if !ok {
+			return ""
+		}
+		return layerOrigin(c, cfg, l)
+	}
+
+	if l := selectedLayer(c); l != LayerUnspecified {
+		values, err := cfg.LayerValues(c, l)
+		if err != nil {
+			return err
+		}
+		store = values
+		origin = func(string) string {
+			return layerOrigin(c, cfg, l)
+		}
+	}
+
+	for _, key := range slices.Sorted(Keys(store)) {
+		if c.Bool("show-origin") {
+			fmt.Fprintf(c.Stdout, "%s\t", origin(key))
+		}
+		value := store.String(key)
+		if cfg.IsSecret(key) {
+			value = cli.Redacted
+		}
+		fmt.Fprintf(c.Stdout, "%s=%s\n", key, value)
+	}
+	return nil
+}
+
+func layerOrigin(c *cli.Context, cfg *Config, l Layer) string {
+	if path, ok := cfg.LayerFile(c, l); ok {
+		return "file:" + path
+	}
+	return layerName(l) + ":"
+}
+
+func configEdit(c *cli.Context) error {
+	cfg := FromContext(c)
+	l, err := writeLayer(c, cfg)
+	if err != nil {
+		return err
+	}
+	data, err := cfg.readLayerFile(c, l)
+	if err != nil {
+		return err
+	}
+
+	editor := &exec.Editor{Data: data}
+	if err := editor.Execute(c); err != nil {
+		return err
+	}
+	return cfg.writeLayerFile(c, l, editor.Output)
+}
+
+func configSecretSet(c *cli.Context) error {
+	value := c.String("value")
+	if !c.Seen("value") {
+		var err error
+		value, err = c.ReadPasswordString("Value: ")
+		fmt.Fprintln(c.Stderr)
+		if err != nil {
+			return err
+		}
+	}
+	return FromContext(c).SetSecret(c, c.String("key"), value)
+}
+
+func configSecretGet(c *cli.Context) error {
+	key := c.String("key")
+	secrets, err := FromContext(c).Secrets(c)
+	if err != nil {
+		return err
+	}
+	if !secrets.Has(key) {
+		return fmt.Errorf("no secret value for %s", key)
+	}
+	fmt.Fprintln(c.Stdout, secrets.String(key))
+	return nil
+}
+
+func configSecretRm(c *cli.Context) error {
+	return FromContext(c).UnsetSecret(c, c.String("key"))
+}
+
+func configSchema(c *cli.Context) error {
+	schema := FromContext(c).Schema()
+
+	w := tabwriter.NewWriter(c.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, name := range slices.Sorted(maps.Keys(schema)) {
+		key := schema[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, key.Type, key.Default, key.description())
+	}
+	return w.Flush()
+}
+
+func configProfiles(c *cli.Context) error {
+	store := FromContext(c).Store()
+
+	w := tabwriter.NewWriter(c.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PROFILE\tMERGE ORDER")
+	for _, name := range ProfileNames(store) {
+		order, err := ResolveProfiles(store, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, strings.Join(order, ", "))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	_ "github.com/Carbonfrost/joe-cli/extensions/marshal/codec/toml"
+	_ "github.com/Carbonfrost/joe-cli/extensions/marshal/codec/yaml"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Commands", func() {
+
+	var (
+		dir    string
+		stdout *bytes.Buffer
+
+		run = func(arguments string) error {
+			app := &cli.App{
+				Name:   "app",
+				Stdout: stdout,
+				Uses: cli.Pipeline(
+					config.New(config.WithLocation(config.Locations(
+						config.LayerLocation(config.LayerUser, filepath.Join(dir, "user.json")),
+						config.LayerLocation(config.LayerWorkspace, filepath.Join(dir, "workspace.json")),
+					))),
+					config.Commands(),
+				),
+			}
+			args, _ := cli.Split(arguments)
+			return app.RunContext(context.Background(), args)
+		}
+		readFile = func(name string) string {
+			data, _ := os.ReadFile(filepath.Join(dir, name))
+			return string(data)
+		}
+		readJSON = func(name string) map[string]any {
+			var res map[string]any
+			Expect(json.Unmarshal([]byte(readFile(name)), &res)).To(Succeed())
+			return res
+		}
+		writeFile = func(name, data string) {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(data), 0644)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		stdout = new(bytes.Buffer)
+		writeFile("user.json", `{"name": "user", "server": {"url": "https://user.example"}}`)
+		writeFile("workspace.json", `{"server": {"port": 8080}}`)
+	})
+
+	It("gets the value from the highest layer", func() {
+		writeFile("workspace.json", `{"name": "ws"}`)
+		Expect(run("app config get name")).To(Succeed())
+		Expect(stdout.String()).To(Equal("ws\n"))
+	})
+
+	It("gets the value from the selected layer", func() {
+		writeFile("workspace.json", `{"name": "ws"}`)
+		Expect(run("app config get --user name")).To(Succeed())
+		Expect(stdout.String()).To(Equal("user\n"))
+	})
+
+	It("returns an error when the value is not set", func() {
+		Expect(run("app config get missing")).To(MatchError("no value for missing"))
+	})
+
+	It("sets the value in the user file preserving types", func() {
+		Expect(run("app config set --user server.url https://example.com")).To(Succeed())
+		Expect(run("app config set --user server.retries 3")).To(Succeed())
+		Expect(run("app config set --user color 007")).To(Succeed())
+		Expect(run("app config set --user name 42")).To(Succeed())
+		Expect(readJSON("user.json")).To(Equal(map[string]any{
+			"name":  "42",
+			"color": "007",
+			"server": map[string]any{
+				"url":     "https://example.com",
+				"retries": 3.0,
+			},
+		}))
+	})
+
+	DescribeTable("preserves comments and the order of keys in the file",
+		func(name, data, arguments, expected string) {
+			writeFile(name, data)
+			app := &cli.App{
+				Name: "app",
+				Uses: cli.Pipeline(
+					config.New(config.WithLocation(config.Locations(
+						config.LayerLocation(config.LayerUser, filepath.Join(dir, name)),
+					))),
+					config.Commands(),
+				),
+			}
+			args, _ := cli.Split(arguments)
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			Expect(readFile(name)).To(Equal(expected))
+		},
+		Entry("YAML set",
+			"user.yaml",
+			"# Server settings\nserver:\n    port: 8080 # default\n    host: localhost\n",
+			"app config set --user server.port 9000",
+			"# Server settings\nserver:\n    port: 9000 # default\n    host: localhost\n",
+		),
+		Entry("YAML unset",
+			"user.yaml",
+			"# Server settings\nserver:\n  port: 8080\n  host: localhost\n",
+			"app config unset --user server.port",
+			"# Server settings\nserver:\n  host: localhost\n",
+		),
+		Entry("TOML set",
+			"user.toml",
+			"# Server settings\n[server]\nport = 8080 # default\nhost = \"localhost\"\n",
+			"app config set --user server.port 9000",
+			"# Server settings\n[server]\nport = 9000 # default\nhost = \"localhost\"\n",
+		),
+		Entry("TOML new key",
+			"user.toml",
+			"# Server settings\n[server]\nport = 8080\n\n[client]\nretries = 3\n",
+			"app config set --user server.host localhost",
+			"# Server settings\n[server]\nport = 8080\nhost = \"localhost\"\n\n[client]\nretries = 3\n",
+		),
+		Entry("TOML unset",
+			"user.toml",
+			"# Server settings\n[server]\nport = 8080 # default\nhost = \"localhost\"\n",
+			"app config unset --user server.port",
+			"# Server settings\n[server]\nhost = \"localhost\"\n",
+		),
+		Entry("JSON set",
+			"user.json",
+			"{\n    \"server\": {\n        \"port\": 8080,\n        \"host\": \"localhost\"\n    }\n}\n",
+			"app config set --user server.url https://example.com",
+			"{\n    \"server\": {\n        \"port\": 8080,\n        \"host\": \"localhost\",\n        \"url\": \"https://example.com\"\n    }\n}\n",
+		),
+		Entry("JSON unset",
+			"user.json",
+			"{\n  \"server\": {\n    \"port\": 8080,\n    \"host\": \"localhost\"\n  }\n}\n",
+			"app config unset --user server.port",
+			"{\n  \"server\": {\n    \"host\": \"localhost\"\n  }\n}\n",
+		),
+	)
+
+	It("sets the items of a list", func() {
+		writeFile("user.json", `{"tags": ["a", "b"], "ports": [80]}`)
+		Expect(run("app config set --user tags c,d")).To(Succeed())
+		Expect(run("app config set --user ports 8080,8443")).To(Succeed())
+		Expect(readFile("user.json")).To(Equal(`{"tags": ["c", "d"], "ports": [8080, 8443]}`))
+	})
+
+	It("returns an error for an unsupported file format", func() {
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.Pipeline(
+				config.New(config.WithLocation(config.Locations(
+					config.LayerLocation(config.LayerUser, filepath.Join(dir, "user.conf")),
+				))),
+				config.Commands(),
+			),
+		}
+		args, _ := cli.Split("app config set --user name user")
+		Expect(app.RunContext(context.Background(), args)).To(MatchError(ContainSubstring(`unsupported configuration file format ".conf"`)))
+	})
+
+	It("sets the value in the workspace by default", func() {
+		Expect(run("app config set server.host localhost")).To(Succeed())
+		Expect(run("app config get --workspace server.host")).To(Succeed())
+		Expect(stdout.String()).To(Equal("localhost\n"))
+		Expect(readFile("workspace.json")).To(ContainSubstring(`"port": 8080`))
+	})
+
+	It("unsets the value", func() {
+		Expect(run("app config unset --user server.url")).To(Succeed())
+		Expect(readJSON("user.json")).To(Equal(map[string]any{"name": "user"}))
+	})
+
+	It("returns an error when unsetting a missing value", func() {
+		Expect(run("app config unset --user missing")).To(MatchError("no value for missing in user config"))
+	})
+
+	It("does not allow more than one layer", func() {
+		Expect(run("app config set --user --workspace a b")).To(MatchError(ContainSubstring("but not both")))
+	})
+
+	It("lists values with their origin", func() {
+		Expect(run("app config list --show-origin")).To(Succeed())
+		Expect(stdout.String()).To(Equal(
+			"file:" + filepath.Join(dir, "user.json") + "\tname=user\n" +
+				"file:" + filepath.Join(dir, "workspace.json") + "\tserver.port=8080\n" +
+				"file:" + filepath.Join(dir, "user.json") + "\tserver.url=https://user.example\n",
+		))
+	})
+
+	It("edits the file using the editor", func() {
+		if runtime.GOOS == "windows" {
+			Skip("requires a shell script editor")
+		}
+		script := filepath.Join(dir, "editor.sh")
+		Expect(os.WriteFile(script, []byte("#!/bin/sh\necho '{\"color\": \"auto\"}' > \"$1\"\n"), 0755)).To(Succeed())
+		os.Setenv("VISUAL", script)
+		DeferCleanup(os.Unsetenv, "VISUAL")
+
+		Expect(run("app config edit --user")).To(Succeed())
+		Expect(readJSON("user.json")).To(Equal(map[string]any{"color": "auto"}))
+	})
+})
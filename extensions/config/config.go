@@ -12,6 +12,8 @@ package config
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	cli "github.com/Carbonfrost/joe-cli"
 	"github.com/Carbonfrost/joe-cli/extensions/bind"
@@ -39,33 +41,49 @@ const (
 
 // The various types that the configuration system supports
 const (
-	BigFloat   = marshal.BigFloat
-	BigInt     = marshal.BigInt
-	Bool       = marshal.Bool
-	Bytes      = marshal.Bytes
-	Duration   = marshal.Duration
-	File       = marshal.File
-	FileSet    = marshal.FileSet
-	Float32    = marshal.Float32
-	Float64    = marshal.Float64
-	Int        = marshal.Int
-	Int16      = marshal.Int16
-	Int32      = marshal.Int32
-	Int64      = marshal.Int64
-	Int8       = marshal.Int8
-	IP         = marshal.IP
-	List       = marshal.List
-	Map        = marshal.Map
-	NameValue  = marshal.NameValue
-	NameValues = marshal.NameValues
-	Regexp     = marshal.Regexp
-	String     = marshal.String
-	Uint       = marshal.Uint
-	Uint16     = marshal.Uint16
-	Uint32     = marshal.Uint32
-	Uint64     = marshal.Uint64
-	Uint8      = marshal.Uint8
-	URL        = marshal.URL
+	Addr             = marshal.Addr
+	AddrPort         = marshal.AddrPort
+	BigFloat         = marshal.BigFloat
+	BigInt           = marshal.BigInt
+	Bool             = marshal.Bool
+	Bytes            = marshal.Bytes
+	ByteSize         = marshal.ByteSize
+	Duration         = marshal.Duration
+	Enum             = marshal.Enum
+	File             = marshal.File
+	FileSet          = marshal.FileSet
+	Float32          = marshal.Float32
+	Float64          = marshal.Float64
+	HardwareAddr     = marshal.HardwareAddr
+	HostPort         = marshal.HostPort
+	Int              = marshal.Int
+	Int16            = marshal.Int16
+	Int32            = marshal.Int32
+	Int64            = marshal.Int64
+	Int8             = marshal.Int8
+	IP               = marshal.IP
+	IPNet            = marshal.IPNet
+	IPRange          = marshal.IPRange
+	List             = marshal.List
+	Map              = marshal.Map
+	NameValue        = marshal.NameValue
+	NameValues       = marshal.NameValues
+	Percent          = marshal.Percent
+	Rate             = marshal.Rate
+	Regexp           = marshal.Regexp
+	Secret           = marshal.Secret
+	SemVer           = marshal.SemVer
+	SemVerConstraint = marshal.SemVerConstraint
+	Set              = marshal.Set
+	SI               = marshal.SI
+	String           = marshal.String
+	Time             = marshal.Time
+	Uint             = marshal.Uint
+	Uint16           = marshal.Uint16
+	Uint32           = marshal.Uint32
+	Uint64           = marshal.Uint64
+	Uint8            = marshal.Uint8
+	URL              = marshal.URL
 )
 
 var (
@@ -77,7 +95,7 @@ var (
 // Options provides the options for the configuration system
 type Options struct {
 
-	// Profile names the profile to load
+	// Profile names the profile to load, or a comma-separated list of profiles
 	Profile string
 
 	// AdditionalFiles specifies additional configuration files to load
@@ -140,7 +158,7 @@ func SetEnvValue(nv ...*cli.NameValue) cli.Action {
 }
 
 // SetProfile sets up the profile and provides reasonable defaults for initializing
-// a flag.
+// a flag.  A comma-separated list of profiles can be specified (see Config.Profiles).
 func SetProfile(name ...string) cli.Action {
 	return cli.Pipeline(
 		&cli.Prototype{
@@ -150,7 +168,21 @@ func SetProfile(name ...string) cli.Action {
 				return FromContext(c).FindProfileNames(c)
 			}),
 		},
-		bind.Action(WithProfile, bind.Exact(name...)),
+		cli.ActionFunc(func(c *cli.Context) error {
+			// The profile must be set before the store is loaded in the Before
+			// pipeline of the command, so it is set by its validator
+			flag := c.Name()
+			return c.Parent().Do(cli.At(cli.ValidatorTiming, cli.ActionFunc(func(c *cli.Context) error {
+				if !c.Seen(flag) {
+					return nil
+				}
+				profile := c.String(flag)
+				if len(name) > 0 {
+					profile = name[0]
+				}
+				return c.Do(WithProfile(profile))
+			})))
+		}),
 	)
 }
 
@@ -224,9 +256,19 @@ type Config struct {
 
 	workspace *Workspace
 
-	store    storeCache
-	options  Options
-	location Location
+	store       storeCache
+	options     Options
+	location    Location
+	schema      Schema
+	literalKeys []string
+
+	secretFile    string
+	secretKeyFile string
+
+	mu             sync.Mutex
+	changeHandlers []func(old, new Store)
+	passphrase     []byte
+	secretKeys     map[string]bool
 }
 
 // Pipeline retrieves the configuration action as a pipeline
@@ -267,6 +309,7 @@ func WithProfile(name string) Option {
 // which is required for most use cases, adds flags, and loads the configuration.
 func New(opts ...Option) *Config {
 	c := new(Config)
+	c.store.post = c.postLoad
 	c.Apply(defaultOpts...)
 	c.Apply(opts...)
 	return c
@@ -300,12 +343,15 @@ func (c *Config) Resolve() ([]IdiomaticLocation, error) {
 	return nil, nil
 }
 
-// FindProfileNames detects the profiles
+// FindProfileNames detects the profiles.  Unless the location provides the
+// profile names, the profiles defined in the store are used (see ProfileNames).
 func (c *Config) FindProfileNames(ctx context.Context) []string {
 	if i, ok := c.location.(IdiomaticLocationProvider); ok {
-		return i.FindProfileNames(ctx)
+		if res := i.FindProfileNames(ctx); len(res) > 0 {
+			return res
+		}
 	}
-	return nil
+	return ProfileNames(c.Store())
 }
 
 // Load loads the configuration if it has not been loaded
@@ -316,9 +362,41 @@ func (c *Config) Load(ctx context.Context) (Store, error) {
 	return c.store.ensureStore(c.upgradeContext(ctx)), c.store.lastError
 }
 
+// postLoad merges the secrets and applies the profiles, schema defaults, and
+// interpolation to the store that was loaded, and then validates it
+func (c *Config) postLoad(ctx context.Context, store Store) (Store, error) {
+	store, err := c.mergeSecrets(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	store, err = c.applyProfiles(store)
+	if err != nil {
+		return nil, err
+	}
+	if c.schema != nil {
+		store = c.schema.withDefaults(store)
+	}
+	store = c.interpolate(ctx, store)
+	if s, ok := store.(*interpolatedStore); ok {
+		if err := s.checkCycles(); err != nil {
+			return nil, err
+		}
+	}
+	if c.schema != nil {
+		if err := c.schema.Validate(store); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
 func (c *Config) loadAction(ctx context.Context) error {
-	_, err := c.Load(ctx)
-	return err
+	store, err := c.Load(ctx)
+	if err != nil {
+		return err
+	}
+	c.warnUnknownKeys(ctx, store)
+	return nil
 }
 
 // Reload loads the configuration or reloads it. This operation does
@@ -362,10 +440,15 @@ func upgradeContext[T interface {
 	return ctx
 }
 
-// WithLocation sets the configuration location to use
+// WithLocation sets the configuration location to use.  When the location provides
+// idiomatic locations (see IdiomaticLocationProvider) and another loader was not
+// specified, the files of the idiomatic locations are the source of the store.
 func WithLocation(l Location) Option {
 	return optionFunc(func(c *Config) {
 		c.location = l
+		if _, ok := l.(IdiomaticLocationProvider); ok && c.store.fn == nil {
+			c.store.fn = c.loadLocations
+		}
 	})
 }
 
@@ -408,6 +491,58 @@ func FlagsAndArgs() cli.Action {
 	}...)
 }
 
+// ConfigKey binds the value of a flag or arg to the configuration value with the
+// given key.  The configuration store provides the value when the flag or arg is not
+// specified on the command line and its value was not obtained from its environment
+// variables or file path.  The resulting precedence is:
+//
+//	argv > EnvVars > FilePath > workspace > user > system > default
+//
+// where the layers of configuration are determined by the store (see LayeredValues).
+// The key is displayed in help when cli.DisplayValueSources is used, and the value
+// source obtained from cli.Context.ValueSource has kind cli.ConfigSource.  An error
+// occurs if the configuration value is not valid for the flag or arg.
+func ConfigKey(key string) cli.Action {
+	return cli.Pipeline(
+		cli.DeclareValueSource(cli.ValueSource{Kind: cli.ConfigSource, Name: key}),
+		cli.ActionFunc(func(c *cli.Context) error {
+			// Hooks run after the Before pipeline of the flag, which is where
+			// values from the environment and file path are set
+			return c.Parent().HookBefore(c.Name(), cli.Implicitly(cli.ActionFunc(func(c *cli.Context) error {
+				return setFromConfig(c, key)
+			})))
+		}),
+	)
+}
+
+func setFromConfig(c *cli.Context, key string) error {
+	if c.ImplicitlySet() {
+		return nil
+	}
+	store := FromContext(c).Store()
+
+	// Only secret values are read from the secret file, which can require
+	// the passphrase to be prompted
+	if _, secret := c.Value("").(*cli.SecretValue); !secret && !store.Has(key) {
+		return nil
+	}
+	value, ok := store.Interface(key)
+	if !ok {
+		return nil
+	}
+
+	source := cli.ValueSource{Kind: cli.ConfigSource, Name: key}
+	if f, ok := store.(LayerFinder); ok {
+		if l, ok := f.Layer(key); ok {
+			source.Layer = strings.ToLower(l.String())
+		}
+	}
+	if err := c.SetValueFrom(source, value); err != nil {
+		return fmt.Errorf("invalid value for %s from config %s: %w", c.Name(), key, err)
+	}
+	return nil
+}
+
 // SetupWorkspaceLink ensures a link between the workspace and Config.
 // It should be present in the initializer action of both. It is part of the
 // default action for both.
@@ -5,16 +5,20 @@
 package config_test
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"net"
 	"net/url"
+	"os"
 	"regexp"
+	"testing/fstest"
 	"time"
 
 	cli "github.com/Carbonfrost/joe-cli"
 	"github.com/Carbonfrost/joe-cli/extensions/config"
 	"github.com/Carbonfrost/joe-cli/extensions/config/configfakes"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
@@ -124,6 +128,106 @@ var _ = Describe("Config", func() {
 
 	})
 
+	Describe("ConfigKey", func() {
+
+		var (
+			layers = config.NewLayeredValues(map[config.Layer]config.Values{
+				config.LayerSystem:    {"server.url": "https://system.example", "server.port": "1"},
+				config.LayerUser:      {"server.url": "https://user.example", "server.port": "2"},
+				config.LayerWorkspace: {"server.port": "3"},
+			})
+			newApp = func(act cli.Action) *cli.App {
+				return &cli.App{
+					Name: "app",
+					FS: fstest.MapFS{
+						"port.txt": {Data: []byte("4")},
+					},
+					Uses: config.New(config.WithStore(layers)),
+					Flags: []*cli.Flag{
+						{
+							Name:     "url",
+							HelpText: "The server URL",
+							EnvVars:  []string{"_GOCLI_CONFIG_URL"},
+							Uses:     config.ConfigKey("server.url"),
+						},
+						{
+							Name:    "port",
+							Value:   cli.Int(),
+							EnvVars: []string{"_GOCLI_CONFIG_PORT"},
+							Uses:    config.ConfigKey("server.port"),
+						},
+						{
+							Name:  "timeout",
+							Value: cli.Duration(),
+							Uses:  config.ConfigKey("server.timeout"),
+						},
+					},
+					Action: act,
+				}
+			}
+		)
+
+		DescribeTable("precedence",
+			func(arguments string, env map[string]string, filePath bool, name string, expected any, expectedSource string) {
+				for k, v := range env {
+					os.Setenv(k, v)
+					DeferCleanup(os.Unsetenv, k)
+				}
+
+				act := new(joeclifakes.FakeAction)
+				app := newApp(act)
+				if filePath {
+					app.Flags[1].FilePath = "port.txt"
+				}
+
+				args, _ := cli.Split(arguments)
+				Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+				c := cli.FromContext(act.ExecuteArgsForCall(0))
+				Expect(c.Value(name)).To(Equal(expected))
+				Expect(c.ValueSource(name).String()).To(Equal(expectedSource))
+			},
+			Entry("argv", "app --port 5", map[string]string{"_GOCLI_CONFIG_PORT": "6"}, true, "port", 5, "argv[1] --port 5"),
+			Entry("env", "app", map[string]string{"_GOCLI_CONFIG_PORT": "6"}, true, "port", 6, "env _GOCLI_CONFIG_PORT"),
+			Entry("file path", "app", nil, true, "port", 4, "file port.txt"),
+			Entry("workspace", "app", nil, false, "port", 3, "config server.port (workspace)"),
+			Entry("user", "app", nil, false, "url", "https://user.example", "config server.url (user)"),
+			Entry("default", "app", nil, false, "timeout", time.Duration(0), "default"),
+		)
+
+		It("distinguishes values implicitly set from config", func() {
+			var fromConfig, fromEnv bool
+			app := newApp(cli.ActionOf(func() {}))
+			app.Flags[1].Action = func(c *cli.Context) {
+				fromConfig = c.ImplicitlySetFrom(cli.ConfigSource)
+				fromEnv = c.ImplicitlySetFrom(cli.EnvSource)
+			}
+			app.Flags[1].Options = cli.ImpliedAction
+
+			Expect(app.RunContext(context.Background(), []string{"app"})).To(Succeed())
+			Expect(fromConfig).To(BeTrue())
+			Expect(fromEnv).To(BeFalse())
+		})
+
+		It("returns an error for an invalid config value", func() {
+			app := newApp(nil)
+			app.Uses = config.New(config.WithStore(config.Values{"server.port": "eighty"}))
+
+			err := app.RunContext(context.Background(), []string{"app"})
+			Expect(err).To(MatchError(ContainSubstring("invalid value for --port from config server.port")))
+		})
+
+		It("displays the key in help", func() {
+			var stderr bytes.Buffer
+			app := newApp(nil)
+			app.Stderr = &stderr
+			app.Uses = cli.Pipeline(app.Uses, cli.DisplayValueSources())
+
+			_ = app.RunContext(context.Background(), []string{"app", "--help"})
+			Expect(stderr.String()).To(ContainSubstring("The server URL [env: _GOCLI_CONFIG_URL, config: server.url]"))
+		})
+	})
+
 	var _ = Describe("Lookup", func() {
 
 		DescribeTableSubtree("examples",
@@ -0,0 +1,245 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	cli "github.com/Carbonfrost/joe-cli"
+)
+
+// WorkspaceCandidate describes a directory which was checked when finding the
+// workspace
+type WorkspaceCandidate struct {
+	// Dir is the directory which was checked
+	Dir string
+
+	// Accepted is set when the directory is a workspace
+	Accepted bool
+
+	// Reason explains why the directory was accepted or rejected
+	Reason string
+}
+
+// WorkspaceExplainer is implemented by a WorkspaceFinder which can explain how it
+// finds the workspace.  This is used for diagnostics and to find the workspaces
+// which contain the workspace (see Workspace.Roots).
+type WorkspaceExplainer interface {
+	// ExplainWorkspacePath gets each of the directories which are checked, starting
+	// with cwd.  Unlike FindWorkspacePath, the search continues after the
+	// first workspace is found so that workspaces which contain it are found.
+	ExplainWorkspacePath(c context.Context, f fs.FS, cwd string) []WorkspaceCandidate
+}
+
+// WithWorkspaceDir pins the workspace directory so that it is used instead of
+// searching for the workspace.  The pinned directory is also the only root of the
+// workspace (see Workspace.Roots), so workspaces which contain it are not found.
+// The workspace directory can also be pinned using an environment variable (see
+// WithWorkspaceDirEnv) or the flag provided by SetWorkspaceDir.
+func WithWorkspaceDir(dir string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.pin(dir, "WithWorkspaceDir")
+		return nil
+	})
+}
+
+// WithWorkspaceDirEnv allows the workspace directory to be pinned (see WithWorkspaceDir)
+// using the environment variable with the given name.  When the name is empty, it
+// is derived from the name of the app, such as APP_WORKSPACE_DIR.
+func WithWorkspaceDirEnv(name string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.dirEnv = name
+		w.dirFromEnv = true
+		return nil
+	})
+}
+
+// SetWorkspaceDir provides the --workspace-dir flag, which pins the workspace
+// directory (see WithWorkspaceDir).  The flag isn't added by NewWorkspace, so add
+// it to the flags of the app to use it.
+func SetWorkspaceDir() cli.Action {
+	return cli.Pipeline(
+		cli.Prototype{
+			Name:     "workspace-dir",
+			HelpText: "Use DIRECTORY as the workspace instead of searching for it",
+			Value:    new(string),
+			Options:  cli.MustExist,
+		},
+		cli.ActionFunc(func(c *cli.Context) error {
+			// The workspace is set up in the Before pipeline of the command,
+			// so the directory is pinned by its validator
+			flag := c.Name()
+			return c.Parent().Do(cli.At(workspaceLoadTiming, cli.ActionFunc(func(c *cli.Context) error {
+				if c.Seen(flag) {
+					WorkspaceFromContext(c).pin(c.String(flag), "--"+strings.TrimLeft(flag, "-"))
+				}
+				return nil
+			})))
+		}),
+	)
+}
+
+// WorkspaceCommands provides an action which adds the workspace command, which has
+// sub-commands to diagnose the workspace:
+//
+//	workspace info                 print the workspace directories and the
+//	                               directories which were checked to find them
+func WorkspaceCommands() cli.Action {
+	return cli.AddCommand(&cli.Command{
+		Name:     "workspace",
+		HelpText: "Diagnose the workspace",
+		Subcommands: []*cli.Command{
+			{
+				Name:     "info",
+				HelpText: "Print the workspace and how it was found",
+				Action:   workspaceInfo,
+			},
+		},
+	})
+}
+
+// Roots gets the directories of the workspace and of the workspaces which contain it,
+// starting with the workspace directory.  In a monorepo where each package has
+// its own configuration directory, this is the package workspace followed by the
+// workspace of the repository.  When the workspace directory is pinned, it is the
+// only root.
+func (w *Workspace) Roots() []string {
+	return w.roots
+}
+
+// Root gets the directory of the outermost workspace, which contains the
+// other workspaces in Roots.  The result is empty if there is no workspace.
+func (w *Workspace) Root() string {
+	if len(w.roots) == 0 {
+		return ""
+	}
+	return w.roots[len(w.roots)-1]
+}
+
+// Candidates gets the directories which were checked to find the workspace
+func (w *Workspace) Candidates() []WorkspaceCandidate {
+	return w.candidates
+}
+
+func (w *Workspace) pin(dir, source string) {
+	w.pinned = dir
+	w.pinnedBy = source
+}
+
+// findRoots finds the workspace directory and the workspaces which contain it,
+// recording the directories which were checked.  The workspace directory is
+// the first root, or empty if no workspace was found.
+func (w *Workspace) findRoots(c context.Context, cwd string) string {
+	if w.pinned == "" && w.dirFromEnv {
+		name := cmp.Or(w.dirEnv, workspaceDirEnvVar(c))
+		if dir, ok := lookupEnv(c, name); ok && dir != "" {
+			w.pin(dir, "env "+name)
+		}
+	}
+
+	if w.pinned != "" {
+		dir, _ := filepath.Abs(w.pinned)
+		w.candidates = []WorkspaceCandidate{{Dir: dir, Accepted: true, Reason: "pinned by " + w.pinnedBy}}
+		w.roots = []string{dir}
+		return dir
+	}
+
+	finder := cmp.Or(w.finder, WorkspaceFinder(DefaultWorkspaceFinder))
+	w.candidates = explainWorkspacePath(c, finder, cwd)
+	w.roots = nil
+	for _, candidate := range w.candidates {
+		if candidate.Accepted {
+			w.roots = append(w.roots, candidate.Dir)
+		}
+	}
+	if len(w.roots) == 0 {
+		return ""
+	}
+	return w.roots[0]
+}
+
+// explainWorkspacePath uses the finder to explain how the workspace is found.  When
+// the finder can't explain, the result only contains the workspace it found.
+func explainWorkspacePath(c context.Context, finder WorkspaceFinder, cwd string) []WorkspaceCandidate {
+	if e, ok := finder.(WorkspaceExplainer); ok {
+		return e.ExplainWorkspacePath(c, actualFS(c), cwd)
+	}
+
+	dir, err := finder.FindWorkspacePath(c, actualFS(c), cwd)
+	if err != nil {
+		return []WorkspaceCandidate{{Dir: cwd, Reason: err.Error()}}
+	}
+	return []WorkspaceCandidate{{Dir: dir, Accepted: true, Reason: "found by the workspace finder"}}
+}
+
+func (d defaultWorkspaceFinder) ExplainWorkspacePath(c context.Context, f fs.FS, cwd string) []WorkspaceCandidate {
+	sentinel := "." + appName(c)
+
+	var res []WorkspaceCandidate
+	for _, ws := range ancestorPaths(cwd) {
+		res = append(res, d.check(f, ws, sentinel))
+	}
+	return res
+}
+
+// check determines whether the directory is a workspace because it contains
+// the sentinel directory
+func (defaultWorkspaceFinder) check(f fs.FS, dir, sentinel string) WorkspaceCandidate {
+	candidate := WorkspaceCandidate{Dir: dir}
+	info, err := fs.Stat(f, filepath.Join(dir, sentinel))
+	switch {
+	case err != nil:
+		candidate.Reason = fmt.Sprintf("no %s directory", sentinel)
+	case !info.IsDir():
+		candidate.Reason = fmt.Sprintf("%s is not a directory", sentinel)
+	default:
+		candidate.Accepted = true
+		candidate.Reason = fmt.Sprintf("contains %s directory", sentinel)
+	}
+	return candidate
+}
+
+// workspaceDirEnvVar gets the name of the environment variable which pins the
+// workspace directory, such as APP_WORKSPACE_DIR
+func workspaceDirEnvVar(c context.Context) string {
+	name := strings.NewReplacer("-", "_", ".", "_").Replace(appName(c))
+	return strings.ToUpper(name) + "_WORKSPACE_DIR"
+}
+
+func workspaceInfo(c *cli.Context) error {
+	ws := WorkspaceFromContext(c)
+
+	w := tabwriter.NewWriter(c.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Workspace:\t%s\n", cmp.Or(ws.Dir(), "(none)"))
+	fmt.Fprintf(w, "Config dir:\t%s\n", ws.ConfigDir())
+	for i, root := range ws.Roots() {
+		label := ""
+		if i == 0 {
+			label = "Roots:"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", label, root)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.Stdout)
+	w = tabwriter.NewWriter(c.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "DIRECTORY\tRESULT\tREASON")
+	for _, candidate := range ws.Candidates() {
+		result := "rejected"
+		if candidate.Accepted {
+			result = "accepted"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", candidate.Dir, result, candidate.Reason)
+	}
+	return w.Flush()
+}
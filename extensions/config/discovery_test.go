@@ -0,0 +1,150 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExplainWorkspacePath", func() {
+
+	testFS := fstest.MapFS{
+		mustLocalize("a/.config"):     {Mode: fs.ModeDir},
+		mustLocalize("a/b/.config"):   {Mode: fs.ModeDir},
+		mustLocalize("a/b/c/.config"): {Data: []byte("file")},
+	}
+
+	It("explains each of the directories which were checked", func() {
+		actual := config.DefaultWorkspaceFinder.ExplainWorkspacePath(context.Background(), testFS, mustLocalize("a/b/c"))
+		Expect(actual).To(Equal([]config.WorkspaceCandidate{
+			{Dir: mustLocalize("a/b/c"), Reason: ".config is not a directory"},
+			{Dir: mustLocalize("a/b"), Accepted: true, Reason: "contains .config directory"},
+			{Dir: "a", Accepted: true, Reason: "contains .config directory"},
+			{Dir: ".", Reason: "no .config directory"},
+		}))
+	})
+
+	It("finds the nearest workspace", func() {
+		actual, err := config.DefaultWorkspaceFinder.FindWorkspacePath(context.Background(), testFS, mustLocalize("a/b/c"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(mustLocalize("a/b")))
+	})
+
+	It("stops at the nearest workspace", func() {
+		var checked []string
+		_, err := config.DefaultWorkspaceFinder.FindWorkspacePath(context.Background(), statFS{testFS, &checked}, mustLocalize("a/b/c"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checked).To(Equal([]string{mustLocalize("a/b/c/.config"), mustLocalize("a/b/.config")}))
+	})
+
+	It("explains why the workspace was not found", func() {
+		_, err := config.DefaultWorkspaceFinder.FindWorkspacePath(context.Background(), testFS, "x")
+		Expect(err).To(MatchError("workspace not found: no .config directory in x or its parent directories"))
+	})
+})
+
+var _ = Describe("Roots", func() {
+
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		ws     *config.Workspace
+
+		run = func(arguments string, opts ...config.WorkspaceOption) error {
+			ws = config.NewWorkspace(opts...)
+			app := &cli.App{
+				Name:   "app",
+				Stdout: stdout,
+				Uses:   cli.Pipeline(ws, config.WorkspaceCommands()),
+				Flags: []*cli.Flag{
+					{Uses: config.SetWorkspaceDir()},
+				},
+				Action: func() {},
+			}
+			args, _ := cli.Split(arguments)
+			return app.RunContext(context.Background(), args)
+		}
+		chdir = func(name string) {
+			Expect(os.Chdir(filepath.Join(dir, name))).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		dir, _ = filepath.EvalSymlinks(GinkgoT().TempDir())
+		stdout = new(bytes.Buffer)
+		Expect(os.MkdirAll(filepath.Join(dir, ".app"), 0755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(dir, "pkg", ".app"), 0755)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(dir, "pkg", "src"), 0755)).To(Succeed())
+
+		cwd, _ := os.Getwd()
+		DeferCleanup(func() {
+			_ = os.Chdir(cwd)
+		})
+	})
+
+	It("finds the workspaces which contain the workspace", func() {
+		chdir("pkg/src")
+		Expect(run("app")).To(Succeed())
+		Expect(ws.Dir()).To(Equal(filepath.Join(dir, "pkg")))
+		Expect(ws.Roots()).To(Equal([]string{filepath.Join(dir, "pkg"), dir}))
+		Expect(ws.Root()).To(Equal(dir))
+	})
+
+	It("pins the workspace using the flag", func() {
+		chdir("pkg/src")
+		Expect(run("app --workspace-dir " + dir)).To(Succeed())
+		Expect(ws.Dir()).To(Equal(dir))
+		Expect(ws.Roots()).To(Equal([]string{dir}))
+		Expect(ws.Candidates()[0]).To(Equal(config.WorkspaceCandidate{
+			Dir: dir, Accepted: true, Reason: "pinned by --workspace-dir",
+		}))
+	})
+
+	It("pins the workspace using the environment variable", func() {
+		GinkgoT().Setenv("APP_WORKSPACE_DIR", filepath.Join(dir, "pkg", "src"))
+		chdir(".")
+		Expect(run("app", config.WithWorkspaceDirEnv(""))).To(Succeed())
+		Expect(ws.Dir()).To(Equal(filepath.Join(dir, "pkg", "src")))
+		Expect(ws.Root()).To(Equal(filepath.Join(dir, "pkg", "src")))
+		Expect(ws.Roots()).To(Equal([]string{filepath.Join(dir, "pkg", "src")}))
+		Expect(ws.Candidates()[0].Reason).To(Equal("pinned by env APP_WORKSPACE_DIR"))
+	})
+
+	It("ignores the environment variable unless it is enabled", func() {
+		GinkgoT().Setenv("APP_WORKSPACE_DIR", filepath.Join(dir, "pkg", "src"))
+		chdir(".")
+		Expect(run("app")).To(Succeed())
+		Expect(ws.Dir()).To(Equal(dir))
+	})
+
+	It("prints the workspace info", func() {
+		chdir("pkg/src")
+		Expect(run("app workspace info")).To(Succeed())
+		Expect(stdout.String()).To(ContainSubstring("Workspace:   " + filepath.Join(dir, "pkg") + "\n"))
+		Expect(stdout.String()).To(ContainSubstring("Roots:       " + filepath.Join(dir, "pkg") + "\n"))
+		Expect(stdout.String()).To(MatchRegexp(`(?m)^` + filepath.Join(dir, "pkg", "src") + ` +rejected +no \.app directory$`))
+		Expect(stdout.String()).To(MatchRegexp(`(?m)^` + dir + ` +accepted +contains \.app directory$`))
+	})
+})
+
+type statFS struct {
+	fstest.MapFS
+	names *[]string
+}
+
+func (f statFS) Stat(name string) (fs.FileInfo, error) {
+	*f.names = append(*f.names, name)
+	return f.MapFS.Stat(name)
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/Carbonfrost/joe-cli/extensions/expr/expander"
+)
+
+// WithDotEnv loads environment variables from dotenv files in the workspace.  The
+// paths are relative to the workspace directory, and files which don't exist
+// are skipped.  Variables from later files take precedence.  The variables are
+// layered over the process environment without modifying it:  they are included in
+// Env, and they are visible to FromEnv and the EnvVars of flags and args (see
+// cli.SetLookupEnv).  The syntax supports comments, an optional export keyword,
+// and quoted values:
+//
+//	# comment
+//	export NAME=value
+//	GREETING="Hello, ${NAME}\n"
+//	PATTERN='${literal}'
+//
+// Within unquoted and double-quoted values, ${VAR} and $VAR are replaced with
+// variables defined earlier in the file or in the environment, and ${VAR:-default}
+// provides a default when the variable is empty.
+func WithDotEnv(paths ...string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.dotEnvFiles = append(w.dotEnvFiles, paths...)
+		return nil
+	})
+}
+
+// LookupEnv looks up an environment variable, which consults the variables from
+// dotenv files, the environment provider, and then the process environment
+func (w *Workspace) LookupEnv(name string) (string, bool) {
+	if v, ok := w.lookupEnv(name); ok {
+		return v, true
+	}
+	return os.LookupEnv(name)
+}
+
+func (w *Workspace) lookupEnv(name string) (string, bool) {
+	if v, ok := w.dotEnv[name]; ok {
+		return v, true
+	}
+	if w.env != nil {
+		for k, v := range w.env.Environ() {
+			if k == name {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (w *Workspace) loadDotEnv() error {
+	if len(w.dotEnvFiles) == 0 {
+		return nil
+	}
+
+	w.dotEnv = EnvMap{}
+	for _, path := range w.dotEnvFiles {
+		data, err := fs.ReadFile(w.FS(), path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := parseDotEnv(string(data), w.dotEnv, w.lookupEnv); err != nil {
+			return fmt.Errorf("%s:%w", path, err)
+		}
+	}
+	return nil
+}
+
+// parseDotEnv parses the dotenv syntax, storing the variables in env.  The lookup
+// function provides variables from outside the file.  Errors are prefixed with
+// the line number.
+func parseDotEnv(data string, env EnvMap, lookup func(string) (string, bool)) error {
+	vars := expander.Compose(
+		expander.Func(func(k string) any {
+			if v, ok := env[k]; ok {
+				return v
+			}
+			if v, ok := lookup(k); ok {
+				return v
+			}
+			return nil
+		}),
+		expander.Env(),
+	)
+
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineno := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "export"); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !isEnvVarName(name) {
+			return fmt.Errorf("%d: expected NAME=VALUE", lineno)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		switch {
+		case strings.HasPrefix(value, "'"), strings.HasPrefix(value, `"`):
+			quote := value[:1]
+			text := value[1:]
+
+			// Quoted values can span lines
+			end := closingQuote(text, quote)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				text += "\n" + lines[i]
+				end = closingQuote(text, quote)
+			}
+			if end < 0 {
+				return fmt.Errorf("%d: missing closing quote", lineno)
+			}
+			if quote == "'" {
+				env[name] = text[:end]
+			} else {
+				env[name] = expandDotEnv(unescapeDotEnv(text[:end]), vars)
+			}
+
+		default:
+			if j := strings.Index(value, " #"); j >= 0 {
+				value = value[:j]
+			}
+			env[name] = expandDotEnv(strings.TrimSpace(value), vars)
+		}
+	}
+	return nil
+}
+
+func closingQuote(s, quote string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == `"` && s[i] == '\\':
+			i++
+		case s[i:i+1] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotEnv(s string) string {
+	return strings.NewReplacer(
+		`\n`, "\n",
+		`\t`, "\t",
+		`\r`, "\r",
+		`\"`, `"`,
+		`\\`, `\`,
+		`\$`, "\x00",
+	).Replace(s)
+}
+
+func expandDotEnv(s string, vars expander.Interface) string {
+	res := os.Expand(s, func(name string) string {
+		name, def, hasDefault := strings.Cut(name, ":-")
+		if v := vars.Expand(name); v != nil {
+			if s := fmt.Sprint(v); s != "" || !hasDefault {
+				return s
+			}
+		}
+		return def
+	})
+
+	// Escaped dollar signs are restored after expansion
+	return strings.ReplaceAll(res, "\x00", "$")
+}
+
+func isEnvVarName(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
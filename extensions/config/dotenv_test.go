@@ -0,0 +1,96 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"testing/fstest"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithDotEnv", func() {
+
+	var run = func(files fstest.MapFS, flags ...*cli.Flag) (*cli.Context, error) {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Uses: config.NewWorkspace(
+				config.WithFS(files),
+				config.WithDotEnv(".env", ".env.local"),
+			),
+			Flags:  flags,
+			Action: act,
+		}
+		if err := app.RunContext(context.Background(), []string{"app"}); err != nil {
+			return nil, err
+		}
+		return cli.FromContext(act.ExecuteArgsForCall(0)), nil
+	}
+
+	DescribeTable("parse",
+		func(data string, name string, expected string) {
+			GinkgoT().Setenv("DOTENV_TEST_HOME", "/home/dotenv")
+			c, err := run(fstest.MapFS{".env": {Data: []byte(data)}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.WorkspaceFromContext(c).Getenv(name)).To(Equal(expected))
+		},
+		Entry("simple", "A=b", "A", "b"),
+		Entry("export", "export A=b", "A", "b"),
+		Entry("comments", "# comment\nA=b # comment", "A", "b"),
+		Entry("single quotes are literal", `A='${X} \n'`, "A", `${X} \n`),
+		Entry("double quotes escapes", `A="x\ty\n\"z\""`, "A", "x\ty\n\"z\""),
+		Entry("double quotes span lines", "A=\"x\ny\"\nB=c", "A", "x\ny"),
+		Entry("interpolates earlier values", "A=b\nC=${A}-$A", "C", "b-b"),
+		Entry("interpolates environment", "A=${DOTENV_TEST_HOME}/bin", "A", "/home/dotenv/bin"),
+		Entry("default value", "A=${DOTENV_TEST_UNSET:-none}", "A", "none"),
+		Entry("escaped dollar", `A="\${A}"`, "A", "${A}"),
+	)
+
+	It("returns an error with the line number", func() {
+		_, err := run(fstest.MapFS{".env": {Data: []byte("A=b\nnot valid\n")}})
+		Expect(err).To(MatchError(".env:2: expected NAME=VALUE"))
+	})
+
+	It("gives precedence to later files and skips missing files", func() {
+		c, err := run(fstest.MapFS{
+			".env":       {Data: []byte("A=b\nC=d")},
+			".env.local": {Data: []byte("A=local")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		ws := config.WorkspaceFromContext(c)
+		Expect(ws.Getenv("A")).To(Equal("local"))
+		Expect(ws.Getenv("C")).To(Equal("d"))
+	})
+
+	It("provides values to flags without changing the process environment", func() {
+		c, err := run(
+			fstest.MapFS{".env": {Data: []byte("DOTENV_TEST_TOKEN=abc")}},
+			&cli.Flag{Name: "token", EnvVars: []string{"DOTENV_TEST_TOKEN"}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.String("token")).To(Equal("abc"))
+		val, _ := c.LookupEnv("DOTENV_TEST_TOKEN")
+		Expect(val).To(Equal("abc"))
+		_, ok := os.LookupEnv("DOTENV_TEST_TOKEN")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("layers the values over the process environment", func() {
+		GinkgoT().Setenv("DOTENV_TEST_OTHER", "from-os")
+		c, err := run(fstest.MapFS{".env": {Data: []byte("A=b")}})
+		Expect(err).NotTo(HaveOccurred())
+		ws := config.WorkspaceFromContext(c)
+		other, _ := ws.LookupEnv("DOTENV_TEST_OTHER")
+		Expect(other).To(Equal("from-os"))
+		a, _ := ws.LookupEnv("A")
+		Expect(a).To(Equal("b"))
+	})
+})
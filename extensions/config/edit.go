@@ -0,0 +1,611 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Carbonfrost/joe-cli/extensions/marshal"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"go.yaml.in/yaml/v3"
+)
+
+// Documents are edited in place so that comments, the order of keys, and the
+// formatting of the file are preserved.  YAML is edited as a tree of nodes.  JSON
+// and TOML are edited by replacing only the text of the member or key which changed.
+// When the document can't be edited in place, such as a value within an array of
+// tables, the values are marshaled again instead.
+
+// jsonSpan is the location of a member of an object in a JSON document.  For the
+// root object, only the location of the value is set.
+type jsonSpan struct {
+	keyStart   int
+	valueStart int
+	valueEnd   int
+}
+
+// tomlEntry is the location of a key/value or table header in a TOML document
+type tomlEntry struct {
+	path       []string
+	table      bool
+	keyStart   int
+	valueStart int
+	valueEnd   int
+
+	// sectionEnd is the end of the last line of the table
+	sectionEnd int
+}
+
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// edit applies an edit to the document in place.  Once an edit fails, the
+// values are marshaled again when the document is written.
+func (d *document) edit(fn func() bool) {
+	if !d.rewrite && !fn() {
+		d.rewrite = true
+	}
+}
+
+func (d *document) editSet(parts []string, value any) bool {
+	switch d.codec {
+	case marshal.YAML:
+		return d.setNode(parts, value)
+	case marshal.JSON:
+		return d.editRaw(setJSON(d.raw, parts, value))
+	case marshal.TOML:
+		return d.editRaw(setTOML(d.raw, parts, value))
+	}
+	return false
+}
+
+// editUnset removes the value.  Removed is the path to the member which no longer
+// exists, which is a parent of the value when the parent is now empty.
+func (d *document) editUnset(parts, removed []string) bool {
+	switch d.codec {
+	case marshal.YAML:
+		return d.unsetNode(removed)
+	case marshal.JSON:
+		return d.editRaw(unsetJSON(d.raw, removed))
+	case marshal.TOML:
+		return d.editRaw(unsetTOML(d.raw, parts))
+	}
+	return false
+}
+
+func (d *document) editRaw(raw []byte, ok bool) bool {
+	if ok {
+		d.raw = raw
+	}
+	return ok
+}
+
+// editedBytes gets the contents of the document which was edited in place.  The
+// result is false if it couldn't be edited or the result doesn't provide the same
+// values as the document.
+func (d *document) editedBytes() ([]byte, bool) {
+	if d.rewrite {
+		return nil, false
+	}
+	data := d.raw
+	if d.node != nil {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(yamlIndent(d.raw))
+		if err := enc.Encode(d.node); err != nil {
+			return nil, false
+		}
+		if err := enc.Close(); err != nil {
+			return nil, false
+		}
+		data = buf.Bytes()
+	}
+
+	edited, err := parseCodecDocument(d.codec, data)
+	if err != nil || !maps.Equal(edited.Values(), d.Values()) {
+		return nil, false
+	}
+	return data, true
+}
+
+func (d *document) setNode(parts []string, value any) bool {
+	if d.node == nil {
+		d.node = &yaml.Node{Kind: yaml.DocumentNode}
+	}
+	if len(d.node.Content) == 0 {
+		d.node.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+
+	m := d.node.Content[0]
+	for i, p := range parts {
+		if m.Kind != yaml.MappingNode {
+			return false
+		}
+		_, v := mappingValue(m, p)
+		if v == nil {
+			v = &yaml.Node{Kind: yaml.MappingNode}
+			m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: p}, v)
+		}
+		if v.Kind == yaml.AliasNode {
+			return false
+		}
+		if i == len(parts)-1 {
+			return setNodeValue(v, value)
+		}
+		if v.Kind != yaml.MappingNode {
+			v.Kind, v.Tag, v.Value, v.Style, v.Content = yaml.MappingNode, "", "", 0, nil
+		}
+		m = v
+	}
+	return false
+}
+
+// setNodeValue sets the value of the node, keeping its comments and the style of
+// the value when possible
+func setNodeValue(n *yaml.Node, value any) bool {
+	var res yaml.Node
+	if err := res.Encode(value); err != nil {
+		return false
+	}
+	switch {
+	case n.Kind == yaml.ScalarNode && res.Kind == yaml.ScalarNode && n.Tag == res.Tag && res.Style == 0:
+		res.Style = n.Style
+	case n.Kind == yaml.SequenceNode && res.Kind == yaml.SequenceNode:
+		res.Style = n.Style
+	}
+	n.Kind, n.Tag, n.Value, n.Style, n.Content = res.Kind, res.Tag, res.Value, res.Style, res.Content
+	return true
+}
+
+func (d *document) unsetNode(parts []string) bool {
+	if d.node == nil || len(d.node.Content) == 0 {
+		return false
+	}
+	m := d.node.Content[0]
+	for _, p := range parts[:len(parts)-1] {
+		if _, m = mappingValue(m, p); m == nil {
+			return false
+		}
+	}
+	i, _ := mappingValue(m, parts[len(parts)-1])
+	if i < 0 {
+		return false
+	}
+	m.Content = slices.Delete(m.Content, i, i+2)
+	return true
+}
+
+// yamlIndent detects the number of spaces used to indent the document, which
+// is the least indentation of its lines
+func yamlIndent(data []byte) int {
+	res := 0
+	for line := range strings.Lines(string(data)) {
+		text := strings.TrimLeft(line, " ")
+		n := len(line) - len(text)
+		if n == 0 || strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if res == 0 || n < res {
+			res = n
+		}
+	}
+	return max(res, 2)
+}
+
+// mappingValue gets the index of the key and the value with the given name
+func mappingValue(m *yaml.Node, name string) (int, *yaml.Node) {
+	if m.Kind != yaml.MappingNode {
+		return -1, nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == name {
+			return i, m.Content[i+1]
+		}
+	}
+	return -1, nil
+}
+
+// scanJSON finds the members of the objects in the document, which are indexed
+// by their path.  Members of objects within arrays are not indexed.
+func scanJSON(data []byte) (map[string]jsonSpan, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	res := map[string]jsonSpan{}
+
+	var scan func(path []string, keyStart int, index bool) error
+	scan = func(path []string, keyStart int, index bool) error {
+		start := skipSpace(data, int(dec.InputOffset()), ":,")
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				keyStart := skipSpace(data, int(dec.InputOffset()), ",")
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := scan(append(slices.Clip(path), key.(string)), keyStart, index); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for dec.More() {
+				if err := scan(nil, 0, false); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		if err != nil {
+			return err
+		}
+		if index {
+			res[jsonPath(path)] = jsonSpan{keyStart, start, int(dec.InputOffset())}
+		}
+		return nil
+	}
+
+	if err := scan(nil, 0, true); err != nil {
+		return nil, false
+	}
+	root, ok := res[""]
+	return res, ok && data[root.valueStart] == '{'
+}
+
+func setJSON(data []byte, parts []string, value any) ([]byte, bool) {
+	spans, ok := scanJSON(data)
+	if !ok {
+		return nil, false
+	}
+	if s, ok := spans[jsonPath(parts)]; ok {
+		text, ok := jsonText(value, "", "")
+		if !ok {
+			return nil, false
+		}
+		return splice(data, s.valueStart, s.valueEnd, text), true
+	}
+
+	// Add the member to the nearest object which exists
+	for i := len(parts) - 1; i >= 0; i-- {
+		s, ok := spans[jsonPath(parts[:i])]
+		if !ok {
+			continue
+		}
+		if data[s.valueStart] != '{' {
+			return nil, false
+		}
+		return insertJSONMember(data, s, parts[i], nestedValue(parts[i+1:], value))
+	}
+	return nil, false
+}
+
+// insertJSONMember adds a member at the end of the object, using the same
+// indentation as the other members
+func insertJSONMember(data []byte, s jsonSpan, key string, value any) ([]byte, bool) {
+	closing := s.valueEnd - 1
+	last := bytes.LastIndexFunc(data[:closing], isNotSpace)
+	closingIndent := lineIndent(data, closing)
+
+	if last == s.valueStart {
+		indent := closingIndent + "  "
+		member, ok := jsonMember(key, value, indent, "  ")
+		if !ok {
+			return nil, false
+		}
+		return splice(data, s.valueStart+1, closing, "\n"+indent+member+"\n"+closingIndent), true
+	}
+
+	if !bytes.Contains(data[last:closing], []byte("\n")) {
+		member, ok := jsonMember(key, value, "", "")
+		if !ok {
+			return nil, false
+		}
+		return splice(data, last+1, last+1, ", "+member), true
+	}
+
+	indent := lineIndent(data, last)
+	member, ok := jsonMember(key, value, indent, cmp.Or(strings.TrimPrefix(indent, closingIndent), "  "))
+	if !ok {
+		return nil, false
+	}
+	return splice(data, last+1, last+1, ",\n"+indent+member), true
+}
+
+// unsetJSON removes the member, including the comma which separates it from the
+// other members
+func unsetJSON(data []byte, parts []string) ([]byte, bool) {
+	spans, ok := scanJSON(data)
+	if !ok {
+		return nil, false
+	}
+	s, ok := spans[jsonPath(parts)]
+	if !ok || len(parts) == 0 {
+		return nil, false
+	}
+
+	start, end := s.keyStart, s.valueEnd
+	after := skipSpace(data, end, "")
+	if after < len(data) && data[after] == ',' {
+		end = skipBlank(data, after+1)
+		lineStart := bytes.LastIndexByte(data[:start], '\n') + 1
+		if len(bytes.TrimSpace(data[lineStart:start])) == 0 && end < len(data) && data[end] == '\n' {
+			start, end = lineStart, end+1
+		}
+		return splice(data, start, end, ""), true
+	}
+
+	before := bytes.LastIndexFunc(data[:start], isNotSpace)
+	switch {
+	case before >= 0 && data[before] == ',':
+		start = before
+	case before >= 0 && data[before] == '{':
+		start, end = before+1, after
+	default:
+		return nil, false
+	}
+	return splice(data, start, end, ""), true
+}
+
+func jsonPath(parts []string) string {
+	return strings.Join(parts, "\x00")
+}
+
+func jsonMember(key string, value any, prefix, indent string) (string, bool) {
+	k, ok := jsonText(key, "", "")
+	if !ok {
+		return "", false
+	}
+	v, ok := jsonText(value, prefix, indent)
+	return k + ": " + v, ok
+}
+
+// jsonText formats the value.  Without indentation, the value is formatted on one
+// line with a space after each separator.
+func jsonText(value any, prefix, indent string) (string, bool) {
+	if indent == "" {
+		var items []string
+		switch v := value.(type) {
+		case []any:
+			for _, item := range v {
+				text, ok := jsonText(item, "", "")
+				if !ok {
+					return "", false
+				}
+				items = append(items, text)
+			}
+			return "[" + strings.Join(items, ", ") + "]", true
+		case map[string]any:
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				member, ok := jsonMember(k, v[k], "", "")
+				if !ok {
+					return "", false
+				}
+				items = append(items, member)
+			}
+			return "{" + strings.Join(items, ", ") + "}", true
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(prefix, indent)
+	if err := enc.Encode(value); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
+// scanTOML finds the key/values and table headers in the document.  Key/values
+// within arrays of tables are omitted because they can't be identified by their path.
+func scanTOML(data []byte) ([]tomlEntry, bool) {
+	var (
+		p     unstable.Parser
+		res   []tomlEntry
+		table *tomlEntry
+		root  = tomlEntry{table: true}
+		inArr bool
+	)
+	p.Reset(data)
+	for p.NextExpression() {
+		e := p.Expression()
+		keyStart, keyEnd, path := tomlKey(e)
+		lineEnd := lineEndAt(data, keyEnd)
+
+		switch e.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			inArr = e.Kind == unstable.ArrayTable
+			if !inArr {
+				res = append(res, tomlEntry{path: path, table: true, keyStart: keyStart, sectionEnd: lineEnd})
+				table = &res[len(res)-1]
+			}
+
+		case unstable.KeyValue:
+			if inArr {
+				continue
+			}
+			valueEnd := int(e.Raw.Offset + e.Raw.Length)
+			sep := keyEnd + bytes.IndexByte(data[keyEnd:], '=')
+			entry := tomlEntry{
+				keyStart:   keyStart,
+				valueStart: skipBlank(data, sep+1),
+				valueEnd:   valueEnd,
+				sectionEnd: lineEndAt(data, valueEnd),
+			}
+			parent := &root
+			if table != nil {
+				parent = table
+				entry.path = slices.Clone(table.path)
+			}
+			entry.path = append(entry.path, path...)
+			parent.sectionEnd = entry.sectionEnd
+			res = append(res, entry)
+		}
+	}
+	if p.Error() != nil {
+		return nil, false
+	}
+	return append(res, root), true
+}
+
+func setTOML(data []byte, parts []string, value any) ([]byte, bool) {
+	entries, ok := scanTOML(data)
+	if !ok {
+		return nil, false
+	}
+	text, ok := tomlText(value)
+	if !ok {
+		return nil, false
+	}
+	for _, e := range entries {
+		if !e.table && slices.Equal(e.path, parts) {
+			return splice(data, e.valueStart, e.valueEnd, text), true
+		}
+	}
+
+	// Add the key to the end of the table with the longest name which contains it,
+	// which is the root table if there is none
+	var table tomlEntry
+	for _, e := range entries {
+		if e.table && len(e.path) < len(parts) && slices.Equal(e.path, parts[:len(e.path)]) && len(e.path) >= len(table.path) {
+			table = e
+		}
+	}
+	line := tomlKeyText(parts[len(table.path):]) + " = " + text + "\n"
+	at := table.sectionEnd
+	if at > 0 && data[at-1] != '\n' {
+		line = "\n" + line
+	}
+	return splice(data, at, at, line), true
+}
+
+// unsetTOML removes the line of the key/value
+func unsetTOML(data []byte, parts []string) ([]byte, bool) {
+	entries, ok := scanTOML(data)
+	if !ok {
+		return nil, false
+	}
+	for _, e := range entries {
+		if e.table || !slices.Equal(e.path, parts) {
+			continue
+		}
+		lineStart := bytes.LastIndexByte(data[:e.keyStart], '\n') + 1
+		if len(bytes.TrimSpace(data[lineStart:e.keyStart])) > 0 {
+			return nil, false
+		}
+		return splice(data, lineStart, e.sectionEnd, ""), true
+	}
+	return nil, false
+}
+
+// tomlKey gets the range and the parts of the key of a key/value or table header
+func tomlKey(e *unstable.Node) (start, end int, path []string) {
+	start = -1
+	it := e.Key()
+	for it.Next() {
+		k := it.Node()
+		if start < 0 {
+			start = int(k.Raw.Offset)
+		}
+		end = int(k.Raw.Offset + k.Raw.Length)
+		path = append(path, string(k.Data))
+	}
+	return
+}
+
+func tomlKeyText(parts []string) string {
+	res := make([]string, len(parts))
+	for i, p := range parts {
+		if bareTOMLKey.MatchString(p) {
+			res[i] = p
+		} else {
+			res[i], _ = jsonText(p, "", "")
+		}
+	}
+	return strings.Join(res, ".")
+}
+
+func tomlText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return jsonText(v, "", "")
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			text, ok := tomlText(item)
+			if !ok {
+				return "", false
+			}
+			items[i] = text
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	}
+	return "", false
+}
+
+// nestedValue gets the value nested in objects with the given names
+func nestedValue(parts []string, value any) any {
+	for i := len(parts) - 1; i >= 0; i-- {
+		value = map[string]any{parts[i]: value}
+	}
+	return value
+}
+
+func splice(data []byte, start, end int, text string) []byte {
+	res := make([]byte, 0, len(data)-(end-start)+len(text))
+	res = append(res, data[:start]...)
+	res = append(res, text...)
+	return append(res, data[end:]...)
+}
+
+// skipSpace gets the index of the first character which is not whitespace or
+// one of the extra characters
+func skipSpace(data []byte, i int, extra string) int {
+	for i < len(data) && (strings.IndexByte(" \t\r\n", data[i]) >= 0 || strings.IndexByte(extra, data[i]) >= 0) {
+		i++
+	}
+	return i
+}
+
+// skipBlank gets the index of the first character which is not a space or tab
+func skipBlank(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// lineEndAt gets the index after the end of the line which contains i
+func lineEndAt(data []byte, i int) int {
+	if n := bytes.IndexByte(data[i:], '\n'); n >= 0 {
+		return i + n + 1
+	}
+	return len(data)
+}
+
+// lineIndent gets the indentation of the line which contains i
+func lineIndent(data []byte, i int) string {
+	start := bytes.LastIndexByte(data[:i], '\n') + 1
+	return string(data[start:skipBlank(data, start)])
+}
+
+func isNotSpace(r rune) bool {
+	return !strings.ContainsRune(" \t\r\n", r)
+}
@@ -0,0 +1,447 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/marshal"
+	"github.com/Carbonfrost/joe-cli/extensions/marshal/codec"
+	"go.yaml.in/yaml/v3"
+)
+
+// document provides the contents of a configuration file in a format provided
+// by a marshal codec, which can be modified and written back.  Nested objects
+// provide the qualified names of values.  The file is edited in place so that
+// its comments and formatting are preserved (see edit.go).
+type document struct {
+	codec marshal.Codec
+	data  map[string]any
+	raw   []byte
+
+	// node is the tree of a YAML document, which is edited instead of raw
+	node *yaml.Node
+
+	// rewrite is set when the document couldn't be edited in place, so the
+	// values are marshaled again
+	rewrite bool
+}
+
+// LayerFile gets the path to the file which stores the values of the given layer.
+// This is the first path of the idiomatic locations of the layer (see LayerLocation).
+// The result is false if there is no location for the layer or the variables in its
+// path could not be resolved, as happens for the workspace layer when there is no
+// workspace.
+func (c *Config) LayerFile(ctx context.Context, layer Layer) (string, bool) {
+	ctx = c.upgradeContext(ctx)
+	for _, loc := range c.idiomaticLocations() {
+		if loc.Layer() != layer {
+			continue
+		}
+		if paths, err := loc.Paths(ctx); err == nil && len(paths) > 0 {
+			return paths[0], true
+		}
+	}
+	return "", false
+}
+
+// LayerValues gets the values which are stored in the file of the given layer
+func (c *Config) LayerValues(ctx context.Context, layer Layer) (Values, error) {
+	doc, err := c.readLayer(ctx, layer)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Values(), nil
+}
+
+// SetLayerValue sets a value in the file of the given layer, creating the file
+// if it does not exist.  The store is invalidated so that it is loaded again.
+func (c *Config) SetLayerValue(ctx context.Context, layer Layer, key, value string) error {
+	return c.updateLayer(ctx, layer, func(doc *document) error {
+		doc.Set(key, value)
+		return nil
+	})
+}
+
+// UnsetLayerValue removes a value from the file of the given layer.  An error
+// is returned if the file does not contain the value.
+func (c *Config) UnsetLayerValue(ctx context.Context, layer Layer, key string) error {
+	return c.updateLayer(ctx, layer, func(doc *document) error {
+		if !doc.Unset(key) {
+			return fmt.Errorf("no value for %s in %s config", key, layerName(layer))
+		}
+		return nil
+	})
+}
+
+// idiomaticLocations gets the locations which support the current platform
+func (c *Config) idiomaticLocations() []IdiomaticLocation {
+	locs, _ := c.Resolve()
+	return slices.DeleteFunc(slices.Clone(locs), func(l IdiomaticLocation) bool {
+		return l.OS() != "" && l.OS() != runtime.GOOS || l.Arch() != "" && l.Arch() != runtime.GOARCH
+	})
+}
+
+// loadLocations loads the files of the idiomatic locations.  When a layer has
+// more than one file, the values of later files take precedence.
+func (c *Config) loadLocations(ctx context.Context) (Store, error) {
+	layers := map[Layer]Values{}
+	positions := map[Layer]map[string]Position{}
+	for _, loc := range c.idiomaticLocations() {
+		paths, err := loc.Paths(ctx)
+		if err != nil {
+			return nil, err
+		}
+		layer := loc.Layer()
+		if layers[layer] == nil {
+			layers[layer] = Values{}
+			positions[layer] = map[string]Position{}
+		}
+		for _, path := range paths {
+			doc, err := readDocument(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range doc.Values() {
+				layers[layer][k] = v
+				positions[layer][k] = Position{File: path, Line: doc.Line(k)}
+			}
+		}
+	}
+
+	res := NewLayeredValues(layers)
+	for k, layer := range res.layers {
+		res.positions[k] = positions[layer][k]
+	}
+	return res, nil
+}
+
+func (c *Config) readLayer(ctx context.Context, layer Layer) (*document, error) {
+	path, ok := c.LayerFile(ctx, layer)
+	if !ok {
+		return nil, fmt.Errorf("no file for %s config", layerName(layer))
+	}
+	return readDocument(ctx, path)
+}
+
+// readLayerFile reads the contents of the file of the layer.  A file which does not
+// exist is treated as empty.
+func (c *Config) readLayerFile(ctx context.Context, layer Layer) ([]byte, error) {
+	path, ok := c.LayerFile(ctx, layer)
+	if !ok {
+		return nil, fmt.Errorf("no file for %s config", layerName(layer))
+	}
+	return readFile(ctx, path)
+}
+
+func (c *Config) writeLayerFile(ctx context.Context, layer Layer, data []byte) error {
+	path, ok := c.LayerFile(ctx, layer)
+	if !ok {
+		return fmt.Errorf("no file for %s config", layerName(layer))
+	}
+	if _, err := parseDocument(path, data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := writeFile(layerFS(ctx), path, data, 0644); err != nil {
+		return err
+	}
+	c.store.Invalidate()
+	return nil
+}
+
+func (c *Config) updateLayer(ctx context.Context, layer Layer, fn func(*document) error) error {
+	doc, err := c.readLayer(ctx, layer)
+	if err != nil {
+		return err
+	}
+	if err := fn(doc); err != nil {
+		return err
+	}
+	data, err := doc.Bytes()
+	if err != nil {
+		return err
+	}
+	return c.writeLayerFile(ctx, layer, data)
+}
+
+// readFile reads the contents of the file.  A file which does not exist is
+// treated as empty.
+func readFile(ctx context.Context, path string) ([]byte, error) {
+	data, err := fs.ReadFile(layerFS(ctx), path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func readDocument(ctx context.Context, path string) (*document, error) {
+	data, err := readFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseDocument(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
+
+func layerName(l Layer) string {
+	return strings.ToLower(l.String())
+}
+
+// layerFS gets the file system used for layer files.  Unlike the workspace, layer
+// files typically have absolute paths, such as those in the home directory
+func layerFS(ctx context.Context) cli.FS {
+	if d, ok := ctx.Value(detachedKey).(*detached); ok {
+		return d.fs
+	}
+	c, ok := cli.TryFromContext(ctx)
+	if !ok || c == nil || c.FS == nil {
+		return cli.DirFS(".")
+	}
+	return cli.NewFS(c.FS)
+}
+
+func writeFile(fsys cli.FS, path string, data []byte, perm fs.FileMode) error {
+	if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	w, ok := f.(io.Writer)
+	if !ok {
+		f.Close()
+		return fmt.Errorf("file is not writable: %s", path)
+	}
+	if _, err := w.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func parseDocument(path string, data []byte) (*document, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return parseCodecDocument(marshal.JSON, data)
+	case ".yaml", ".yml":
+		return parseCodecDocument(marshal.YAML, data)
+	case ".toml":
+		return parseCodecDocument(marshal.TOML, data)
+	default:
+		return nil, fmt.Errorf("unsupported configuration file format %q", ext)
+	}
+}
+
+func parseCodecDocument(c marshal.Codec, data []byte) (*document, error) {
+	doc := &document{codec: c, data: map[string]any{}, raw: data}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return doc, nil
+	}
+	if c == marshal.YAML {
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err == nil && node.Kind == yaml.DocumentNode {
+			doc.node = &node
+		}
+	}
+	impl, err := c.New()
+	if err != nil {
+		return nil, err
+	}
+	if err := (codec.Codec{Interface: impl}).Unmarshal(data, &doc.data); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (d *document) Values() Values {
+	res := Values{}
+	flattenInto(res, "", d.data)
+	return res
+}
+
+// flattenInto provides the values using their qualified names.  Lists are joined
+// with commas, which is the syntax of Values.List; the document keeps the list
+// itself, so it is preserved when the file is written.
+func flattenInto(res Values, prefix string, data map[string]any) {
+	for k, v := range data {
+		switch v := v.(type) {
+		case map[string]any:
+			flattenInto(res, prefix+k+".", v)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			res[prefix+k] = strings.Join(items, ",")
+		case nil:
+			res[prefix+k] = ""
+		default:
+			res[prefix+k] = fmt.Sprint(v)
+		}
+	}
+}
+
+func (d *document) Set(key, value string) {
+	parts := strings.Split(key, ".")
+	m := d.data
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := m[p].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			m[p] = child
+		}
+		m = child
+	}
+	name := parts[len(parts)-1]
+	v := setValue(m[name], value)
+	m[name] = v
+	d.edit(func() bool { return d.editSet(parts, v) })
+}
+
+// setValue converts the text to the value which is set.  When the existing value
+// is a list, the text is split into the items of the list.
+func setValue(existing any, text string) any {
+	items, ok := existing.([]any)
+	if !ok {
+		return scalarValue(existing, text)
+	}
+
+	var sample any
+	if len(items) > 0 {
+		sample = items[0]
+	}
+	res := []any{}
+	for _, item := range cli.SplitList(text, ",", -1) {
+		res = append(res, scalarValue(sample, item))
+	}
+	return res
+}
+
+// scalarValue converts the text to a bool or number unless the existing value is
+// a string, which preserves the type of the value in the file.  The text is only
+// converted when it is the canonical form of the bool or number, so 8080 becomes
+// a number, but 007 remains a string.
+func scalarValue(existing any, text string) any {
+	if _, ok := existing.(string); ok {
+		return text
+	}
+	if b, err := strconv.ParseBool(text); err == nil && strconv.FormatBool(b) == text {
+		return b
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil && strconv.FormatInt(n, 10) == text {
+		return n
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == text {
+		return f
+	}
+	return text
+}
+
+func (d *document) Unset(key string) bool {
+	parts := strings.Split(key, ".")
+	if !unsetPath(d.data, parts) {
+		return false
+	}
+
+	// Parents which are now empty were removed too
+	removed := parts
+	for len(removed) > 1 && !hasPath(d.data, removed[:len(removed)-1]) {
+		removed = removed[:len(removed)-1]
+	}
+	d.edit(func() bool { return d.editUnset(parts, removed) })
+	return true
+}
+
+func hasPath(m map[string]any, parts []string) bool {
+	for i, p := range parts {
+		v, ok := m[p]
+		if !ok {
+			return false
+		}
+		if i < len(parts)-1 {
+			if m, ok = v.(map[string]any); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func unsetPath(m map[string]any, parts []string) bool {
+	if len(parts) == 1 {
+		_, ok := m[parts[0]]
+		delete(m, parts[0])
+		return ok
+	}
+	child, ok := m[parts[0]].(map[string]any)
+	if !ok || !unsetPath(child, parts[1:]) {
+		return false
+	}
+	if len(child) == 0 {
+		delete(m, parts[0])
+	}
+	return true
+}
+
+// Line finds the line using the segments of the key in order.  The codecs don't
+// report positions, so this is approximate.
+func (d *document) Line(key string) int {
+	lines := strings.Split(string(d.raw), "\n")
+	start := 0
+	for _, segment := range strings.Split(key, ".") {
+		found := false
+		for i := start; i < len(lines); i++ {
+			if definesName(lines[i], segment) {
+				start, found = i, true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	return start + 1
+}
+
+func definesName(line, name string) bool {
+	line = strings.TrimLeft(line, " \t{[")
+	line = strings.TrimPrefix(line, `"`)
+	rest, ok := strings.CutPrefix(line, name)
+	if !ok {
+		return false
+	}
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, `"`), " \t")
+	return strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, "]")
+}
+
+func (d *document) Bytes() ([]byte, error) {
+	if data, ok := d.editedBytes(); ok {
+		return data, nil
+	}
+	impl, err := d.codec.New(marshal.WithIndent("  "))
+	if err != nil {
+		impl, err = d.codec.New()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codec.Codec{Interface: impl}.Marshal(d.data)
+}
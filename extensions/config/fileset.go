@@ -0,0 +1,386 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"iter"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// fileFilter determines which files are in a file set using glob patterns
+// and ignore files
+type fileFilter struct {
+	include     []string
+	exclude     []string
+	ignoreFiles []string
+}
+
+// ignoreRule is a rule from an ignore file
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// fileIndex caches the results of the loader for each file
+type fileIndex struct {
+	Files map[string]fileIndexEntry `json:"files"`
+}
+
+type fileIndexEntry struct {
+	ModTime time.Time       `json:"modTime"`
+	Size    int64           `json:"size"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// WithInclude specifies glob patterns for the files in the workspace.  When
+// set, only the files which match one of the patterns are in the result of the
+// [Workspace.Files] and [Workspace.LoadFiles] methods.  The syntax of patterns is
+// the same as the syntax of ignore files (see WithIgnoreFiles) so that *.go matches
+// files in any directory, and docs/**/*.md matches files under docs.
+func WithInclude(patterns ...string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.files.filter.include = append(w.files.filter.include, patterns...)
+		return nil
+	})
+}
+
+// WithExclude specifies glob patterns for files and directories that are not in
+// the workspace files.  The syntax is the same as WithInclude.
+func WithExclude(patterns ...string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.files.filter.exclude = append(w.files.filter.exclude, patterns...)
+		return nil
+	})
+}
+
+// WithIgnoreFiles specifies the names of ignore files, such as .gitignore.  An
+// ignore file in any directory of the workspace lists patterns for the files and
+// directories within that directory which are not in the workspace files.  The syntax
+// is that of .gitignore:  blank lines and lines starting with # are skipped; a pattern
+// starting with ! includes a file again; a pattern ending with / only matches
+// directories; a pattern which contains / is relative to the directory of the
+// ignore file, otherwise it matches the name at any level; and ** matches any
+// number of directories.
+func WithIgnoreFiles(names ...string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.files.filter.ignoreFiles = append(w.files.filter.ignoreFiles, names...)
+		return nil
+	})
+}
+
+// WithFileIndex enables the index, which caches the results of the loader across
+// invocations of the app.  The index is stored with the given name in the cache
+// directory of the app (${cli:cache}), and there is a separate index for each
+// workspace directory, type of value, and version of the app.  A file is only loaded
+// again when its modification time or size changes.
+//
+// The results of the loader are stored as JSON, so the index is only used by
+// file sets created with NewFileSet whose type T is not an interface and supports
+// encoding/json.  It isn't used by the workspace's own file set (see
+// [Workspace.LoadFiles]), nor when the file system is set using WithFS, because
+// its root can't be identified.  If a loader changes the values that it provides
+// for a type, change the name of the index or the version of the app.
+func WithFileIndex(name string) WorkspaceOption {
+	return workspaceOption(func(w *Workspace) error {
+		w.files.index = name
+		return nil
+	})
+}
+
+// Query loads the files in the file set whose names match the pattern, which
+// uses the same syntax as WithInclude.  Only the files which match are loaded.
+func (s *WorkspaceFileSet[T]) Query(pattern string) iter.Seq[T] {
+	return s.load(func(name string) bool {
+		return matchPattern(pattern, name)
+	})
+}
+
+// Query loads the files in the workspace whose names match the pattern.  It delegates
+// to the workspace's [Workspace.FileSet].
+func (w *Workspace) Query(pattern string) iter.Seq[any] {
+	return w.FileSet().Query(pattern)
+}
+
+func (s *WorkspaceFileSet[T]) load(match func(string) bool, diropt ...string) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		index := s.readIndex()
+		seen := map[string]bool{}
+		changed := false
+
+		err := s.walkDir(func(name string, d fs.DirEntry, _ error) error {
+			key := path.Join(append(slashPaths(diropt), name)...)
+			if match != nil && !match(key) {
+				return nil
+			}
+			seen[key] = true
+
+			loaded, ok := s.lookupIndex(index, key, d)
+			if !ok {
+				var err error
+				loaded, err = s.loader(s.f, name, d)
+				if err != nil {
+					return err
+				}
+				changed = s.updateIndex(index, key, d, loaded) || changed
+			}
+
+			if !yield(loaded) {
+				return fs.SkipAll
+			}
+			return nil
+		}, diropt...)
+
+		// Files which no longer exist are removed when every file was visited
+		if index != nil && err == nil && match == nil && len(diropt) == 0 {
+			for key := range index.Files {
+				if !seen[key] {
+					delete(index.Files, key)
+					changed = true
+				}
+			}
+		}
+		if changed {
+			_ = s.writeIndex(index)
+		}
+	}
+}
+
+// skip determines whether the file or directory is not in the file set.  The name
+// is relative to the root of the workspace.
+func (f *fileFilter) skip(fsys fs.FS, name string, isDir bool, rules map[string][]ignoreRule) bool {
+	for _, pattern := range f.exclude {
+		if matchPattern(pattern, name) {
+			return true
+		}
+	}
+	if f.ignored(fsys, name, isDir, rules) {
+		return true
+	}
+	if isDir || len(f.include) == 0 {
+		return false
+	}
+	for _, pattern := range f.include {
+		if matchPattern(pattern, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ignored applies the rules from the ignore files in the ancestor directories of
+// the file.  The rules of each directory are cached in rules.
+func (f *fileFilter) ignored(fsys fs.FS, name string, isDir bool, rules map[string][]ignoreRule) bool {
+	if len(f.ignoreFiles) == 0 {
+		return false
+	}
+
+	var res bool
+	dir := path.Dir(name)
+	for _, ancestor := range slashAncestors(dir) {
+		dirRules, ok := rules[ancestor]
+		if !ok {
+			dirRules = f.readIgnoreFiles(fsys, ancestor)
+			rules[ancestor] = dirRules
+		}
+
+		rel := name
+		if ancestor != "." {
+			rel = strings.TrimPrefix(name, ancestor+"/")
+		}
+		for _, r := range dirRules {
+			if r.dirOnly && !isDir {
+				continue
+			}
+			if matchPattern(r.pattern, rel) {
+				res = !r.negate
+			}
+		}
+	}
+	return res
+}
+
+func (f *fileFilter) readIgnoreFiles(fsys fs.FS, dir string) []ignoreRule {
+	var res []ignoreRule
+	for _, name := range f.ignoreFiles {
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		res = append(res, parseIgnoreFile(data)...)
+	}
+	return res
+}
+
+func parseIgnoreFile(data []byte) []ignoreRule {
+	var res []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var r ignoreRule
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			r.negate = true
+			line = rest
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			r.dirOnly = true
+			line = rest
+		}
+		r.pattern = line
+		res = append(res, r)
+	}
+	return res
+}
+
+// matchPattern matches the name, which uses slashes, against the pattern.  A pattern
+// without a slash matches the last element of the name at any level, otherwise
+// the pattern matches the entire name.  ** matches any number of directories.
+func matchPattern(pattern, name string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "./"), "/")
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+	return matchGlob(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlob(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func (s *WorkspaceFileSet[T]) indexPath() string {
+	if s.index == "" || s.cacheDir == "" || s.dir == "" || s.indexFS == nil {
+		return ""
+	}
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Interface {
+		return ""
+	}
+
+	key := strings.Join([]string{s.dir, typ.PkgPath(), typ.String(), s.version}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.cacheDir, "index", s.index+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+func (s *WorkspaceFileSet[T]) readIndex() *fileIndex {
+	file := s.indexPath()
+	if file == "" {
+		return nil
+	}
+
+	res := &fileIndex{}
+	if data, err := fs.ReadFile(s.indexFS, file); err == nil {
+		_ = json.Unmarshal(data, res)
+	}
+	if res.Files == nil {
+		res.Files = map[string]fileIndexEntry{}
+	}
+	return res
+}
+
+func (s *WorkspaceFileSet[T]) writeIndex(index *fileIndex) error {
+	file := s.indexPath()
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	// Replace the file so that other invocations don't read a partial index
+	tmp := file + ".tmp"
+	if err := writeFile(s.indexFS, tmp, data, 0644); err != nil {
+		return err
+	}
+	return s.indexFS.Rename(tmp, file)
+}
+
+func (s *WorkspaceFileSet[T]) lookupIndex(index *fileIndex, key string, d fs.DirEntry) (T, bool) {
+	var res T
+	if index == nil {
+		return res, false
+	}
+	entry, ok := index.Files[key]
+	if !ok {
+		return res, false
+	}
+	info, err := d.Info()
+	if err != nil || !info.ModTime().Equal(entry.ModTime) || info.Size() != entry.Size {
+		return res, false
+	}
+	if err := json.Unmarshal(entry.Value, &res); err != nil {
+		return res, false
+	}
+	return res, true
+}
+
+func (s *WorkspaceFileSet[T]) updateIndex(index *fileIndex, key string, d fs.DirEntry, loaded T) bool {
+	if index == nil {
+		return false
+	}
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+	value, err := json.Marshal(loaded)
+	if err != nil {
+		delete(index.Files, key)
+		return true
+	}
+	index.Files[key] = fileIndexEntry{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Value:   value,
+	}
+	return true
+}
+
+// slashAncestors gets the directory and its ancestors starting with the root
+func slashAncestors(dir string) []string {
+	var res []string
+	for ; dir != "." && dir != "/" && dir != ""; dir = path.Dir(dir) {
+		res = append([]string{dir}, res...)
+	}
+	return append([]string{"."}, res...)
+}
+
+func slashPaths(paths []string) []string {
+	res := make([]string, len(paths))
+	for i, p := range paths {
+		res[i] = filepath.ToSlash(p)
+	}
+	return res
+}
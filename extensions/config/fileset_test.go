@@ -0,0 +1,165 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing/fstest"
+	"time"
+
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkspaceFileSet", func() {
+
+	var files = func(ws *config.Workspace) []string {
+		config.CompleteSetup(context.Background(), ws)
+		return slices.Collect(maps.Keys(maps.Collect(ws.Files())))
+	}
+
+	It("filters files using include and exclude patterns", func() {
+		ws := config.NewWorkspace(
+			config.WithFS(fstest.MapFS{
+				"a.go":         {},
+				"b.txt":        {},
+				"vendor/x.go":  {},
+				"cmd/app/m.go": {},
+				"docs/a/b.md":  {},
+				"docs/c.txt":   {},
+			}),
+			config.WithInclude("*.go", "docs/**/*.md"),
+			config.WithExclude("vendor"),
+		)
+		Expect(files(ws)).To(ConsistOf("a.go", "cmd/app/m.go", "docs/a/b.md"))
+	})
+
+	It("skips files from ignore files", func() {
+		ws := config.NewWorkspace(
+			config.WithFS(fstest.MapFS{
+				".gitignore":     {Data: []byte("# comment\n*.log\nbuild/\n!keep.log\n")},
+				"a.log":          {},
+				"keep.log":       {},
+				"build/out":      {},
+				"main.go":        {},
+				"src/.gitignore": {Data: []byte("/gen.go\n")},
+				"src/gen.go":     {},
+				"src/x/gen.go":   {},
+				"src/x/a.log":    {},
+			}),
+			config.WithIgnoreFiles(".gitignore"),
+		)
+		Expect(files(ws)).To(ConsistOf(".gitignore", "keep.log", "main.go", "src/.gitignore", "src/x/gen.go"))
+	})
+
+	It("loads only the files which match the query", func() {
+		var loaded []string
+		ws := config.NewWorkspace(
+			config.WithFS(fstest.MapFS{
+				"a.md":     {},
+				"b/c.md":   {},
+				"b/d.json": {},
+			}),
+		)
+		config.CompleteSetup(context.Background(), ws)
+
+		fileSet := config.NewFileSet(ws, func(_ fs.FS, name string, _ fs.DirEntry) (string, error) {
+			loaded = append(loaded, name)
+			return "data:" + name, nil
+		})
+		Expect(slices.Collect(fileSet.Query("*.md"))).To(ConsistOf("data:a.md", "data:b/c.md"))
+		Expect(loaded).To(ConsistOf("a.md", "b/c.md"))
+	})
+
+	Describe("index", func() {
+
+		type doc struct {
+			Name string
+		}
+
+		var (
+			dir     string
+			modTime time.Time
+			loaded  []string
+
+			writeFile = func(name, data string, modTime time.Time) {
+				file := filepath.Join(dir, name)
+				Expect(os.WriteFile(file, []byte(data), 0644)).To(Succeed())
+				Expect(os.Chtimes(file, modTime, modTime)).To(Succeed())
+			}
+
+			workspace = func(options ...config.WorkspaceOption) *config.Workspace {
+				ws := config.NewWorkspace(append([]config.WorkspaceOption{
+					config.WithWorkspaceDir(dir),
+					config.WithFileIndex("docs"),
+					config.WithFileLoader(func(_ fs.FS, name string, _ fs.DirEntry) (any, error) {
+						loaded = append(loaded, name)
+						return doc{Name: name}, nil
+					}),
+				}, options...)...)
+				config.CompleteSetup(context.Background(), ws)
+				return ws
+			}
+
+			load = func() []doc {
+				fileSet := config.NewFileSet(workspace(), func(_ fs.FS, name string, _ fs.DirEntry) (doc, error) {
+					loaded = append(loaded, name)
+					return doc{Name: name}, nil
+				})
+				return slices.Collect(fileSet.LoadFiles())
+			}
+		)
+
+		BeforeEach(func() {
+			cache := GinkgoT().TempDir()
+			GinkgoT().Setenv("XDG_CACHE_HOME", cache)
+			GinkgoT().Setenv("HOME", cache)
+			GinkgoT().Setenv("LocalAppData", cache)
+
+			dir = GinkgoT().TempDir()
+			modTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			writeFile("a", "a", modTime)
+			writeFile("b", "b", modTime)
+			loaded = nil
+		})
+
+		It("uses the cached results across invocations", func() {
+			Expect(load()).To(ConsistOf(doc{"a"}, doc{"b"}))
+			Expect(load()).To(ConsistOf(doc{"a"}, doc{"b"}))
+			Expect(loaded).To(ConsistOf("a", "b"))
+		})
+
+		It("loads files which changed", func() {
+			load()
+			writeFile("b", "bb", modTime.Add(time.Hour))
+			writeFile("c", "c", modTime)
+
+			Expect(load()).To(ConsistOf(doc{"a"}, doc{"b"}, doc{"c"}))
+			Expect(loaded).To(Equal([]string{"a", "b", "b", "c"}))
+		})
+
+		It("is not used by the workspace loader", func() {
+			Expect(slices.Collect(workspace().LoadFiles())).To(ConsistOf(doc{"a"}, doc{"b"}))
+			Expect(slices.Collect(workspace().LoadFiles())).To(ConsistOf(doc{"a"}, doc{"b"}))
+			Expect(loaded).To(HaveLen(4))
+		})
+
+		It("is not used with the file system from WithFS", func() {
+			fileSet := config.NewFileSet(workspace(config.WithFS(os.DirFS(dir))), func(_ fs.FS, name string, _ fs.DirEntry) (doc, error) {
+				loaded = append(loaded, name)
+				return doc{Name: name}, nil
+			})
+			Expect(slices.Collect(fileSet.LoadFiles())).To(HaveLen(2))
+			Expect(slices.Collect(fileSet.LoadFiles())).To(HaveLen(2))
+			Expect(loaded).To(HaveLen(4))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/template"
+)
+
+// initGenerator creates the configuration directory and runs the generators
+// within it.  The directory is determined for each invocation of the command.
+type initGenerator struct {
+	generators template.Sequence
+}
+
+const initDirKey key = "initDir"
+
+// InitWorkspace provides an action which adds the init command, which creates a
+// workspace in the current directory.  The workspace is created by making the
+// configuration directory, which has the same name as the app with a leading
+// period, and running the generators within it:
+//
+//	config.InitWorkspace(
+//		template.File("config.toml", template.ContentsString("# settings\n")),
+//		template.Dir("scripts", template.File("build.sh", template.Mode(template.Executable))),
+//	)
+//
+// The command has the --dry-run and --overwrite flags of template.Root.  Without
+// --overwrite, the command fails if the directory already is a workspace.  It also
+// fails when the directory is within another workspace unless --force is set.
+// When the workspace directory is pinned (see WithWorkspaceDir), the workspace is
+// created there rather than in the current directory.
+func InitWorkspace(generators ...template.Generator) cli.Action {
+	root := template.New(&initGenerator{generators: generators})
+
+	return cli.AddCommand(&cli.Command{
+		Name:     "init",
+		HelpText: "Create a workspace in the current directory",
+		Flags: []*cli.Flag{
+			{
+				Name:     "force",
+				HelpText: "Create the workspace even if it is within another workspace",
+				Value:    new(bool),
+			},
+		},
+		Uses: root,
+		Before: cli.ActionFunc(func(c *cli.Context) error {
+			dir, err := initWorkspaceDir(c)
+			if err != nil {
+				return err
+			}
+			return c.SetContextValue(initDirKey, dir)
+		}),
+	})
+}
+
+// initWorkspaceDir gets the configuration directory for the new workspace and
+// checks whether it can be created.  The workspace is created in the directory
+// which pins the workspace (see WithWorkspaceDir), otherwise the working directory,
+// which reflects --chdir.
+func initWorkspaceDir(c *cli.Context) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	finder := WorkspaceFinder(DefaultWorkspaceFinder)
+	ws, _ := tryWorkspaceFromContext(c)
+	if ws != nil {
+		finder = cmp.Or(ws.finder, finder)
+		if ws.pinned != "" {
+			dir, _ = filepath.Abs(ws.pinned)
+		}
+	}
+
+	existing, err := finder.FindWorkspacePath(c, actualFS(c), dir)
+	switch {
+	case errors.Is(err, errWorkspaceNotFound):
+	case err != nil:
+		return "", err
+	case existing == dir:
+		if !c.Bool("overwrite") {
+			return "", fmt.Errorf("workspace already exists in %s (use --overwrite to update its files)", dir)
+		}
+	case !c.Bool("force"):
+		return "", fmt.Errorf("%s is within the workspace %s (use --force to create a nested workspace)", dir, existing)
+	}
+
+	// The configuration directory of the workspace reflects --config-dir
+	if ws != nil && ws.Dir() == dir && ws.ConfigDir() != "" {
+		return ws.ConfigDir(), nil
+	}
+	return filepath.Join(dir, "."+appName(c)), nil
+}
+
+// Generate creates the configuration directory so that it exists even if
+// no files are generated, and then runs the generators within it
+func (g *initGenerator) Generate(ctx context.Context, c *template.OutputContext) error {
+	dir, _ := ctx.Value(initDirKey).(string)
+	if !c.DryRun {
+		if err := c.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	if err := c.PushDir(dir); err != nil {
+		return err
+	}
+	if err := g.generators.Generate(ctx, c); err != nil {
+		return err
+	}
+	return c.PopDir()
+}
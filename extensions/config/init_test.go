@@ -0,0 +1,128 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	"github.com/Carbonfrost/joe-cli/extensions/template"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("InitWorkspace", func() {
+
+	var (
+		cwd    string
+		files  memFS
+		stdout *bytes.Buffer
+
+		run = func(arguments string) error {
+			app := &cli.App{
+				Name:   "app",
+				FS:     files,
+				Stdout: stdout,
+				Uses: cli.Pipeline(
+					config.NewWorkspace(),
+					config.InitWorkspace(
+						template.File("config.toml", template.ContentsString("name = app\n")),
+					),
+				),
+			}
+			args, _ := cli.Split(arguments)
+			return app.RunContext(context.Background(), args)
+		}
+	)
+
+	BeforeEach(func() {
+		SkipOnWindows()
+		cwd, _ = os.Getwd()
+		files = memFS{afero.NewMemMapFs()}
+		stdout = new(bytes.Buffer)
+	})
+
+	It("creates the config dir with the generated files", func() {
+		Expect(run("app init")).To(Succeed())
+
+		data, err := fs.ReadFile(files, filepath.Join(cwd, ".app", "config.toml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("name = app\n"))
+	})
+
+	It("does not create files in a dry run", func() {
+		Expect(run("app init --dry-run")).To(Succeed())
+		Expect(stdout.String()).To(ContainSubstring("(dry-run)"))
+
+		_, err := files.Stat(filepath.Join(cwd, ".app"))
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("requires overwrite when the workspace exists", func() {
+		Expect(files.MkdirAll(filepath.Join(cwd, ".app"), 0755)).To(Succeed())
+
+		Expect(run("app init")).To(MatchError(ContainSubstring("workspace already exists in " + cwd)))
+		Expect(run("app init --overwrite")).To(Succeed())
+	})
+
+	It("creates the workspace in the pinned directory", func() {
+		dir := filepath.Join(cwd, "testdata", "pinned")
+		app := &cli.App{
+			Name:   "app",
+			FS:     files,
+			Stdout: stdout,
+			Uses: cli.Pipeline(
+				config.NewWorkspace(config.WithWorkspaceDir(dir)),
+				config.InitWorkspace(
+					template.File("config.toml", template.ContentsString("name = app\n")),
+				),
+			),
+		}
+		Expect(app.RunContext(context.Background(), []string{"app", "init"})).To(Succeed())
+		Expect(run("app init")).To(Succeed())
+
+		_, err := files.Stat(filepath.Join(dir, ".app", "config.toml"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = files.Stat(filepath.Join(cwd, ".app", "config.toml"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("refuses to create a nested workspace unless forced", func() {
+		parent := filepath.Dir(cwd)
+		Expect(files.MkdirAll(filepath.Join(parent, ".app"), 0755)).To(Succeed())
+
+		Expect(run("app init")).To(MatchError(cwd + " is within the workspace " + parent + " (use --force to create a nested workspace)"))
+		Expect(run("app init --force")).To(Succeed())
+
+		_, err := files.Stat(filepath.Join(cwd, ".app", "config.toml"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+type memFS struct {
+	afero.Fs
+}
+
+func (m memFS) Create(name string) (fs.File, error) {
+	return m.Fs.Create(name)
+}
+
+func (m memFS) Open(name string) (fs.File, error) {
+	return m.Fs.Open(name)
+}
+
+func (m memFS) OpenContext(_ context.Context, name string) (fs.File, error) {
+	return m.Fs.Open(name)
+}
+
+func (m memFS) OpenFile(name string, flag int, perm fs.FileMode) (fs.File, error) {
+	return m.Fs.OpenFile(name, flag, perm)
+}
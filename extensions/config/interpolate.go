@@ -0,0 +1,369 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/expr/expander"
+)
+
+// WithLiteralKeys sets the keys whose values are not interpolated.  Each name
+// can be a pattern using the syntax of path.Match, so that alias.* matches all of
+// the keys qualified by alias.
+//
+// Otherwise, string values in the store are interpolated each time they are read.
+// ${NAME} is replaced with the value of the config key NAME, such as
+// ${server.host}:${server.port}, which is itself interpolated.  When there is no such
+// key, the variable can be one of the special variables supported by ParseLocation,
+// such as ${cli:workspace}, or an environment variable such as ${HOME} or $HOME.
+// Variables which can't be resolved are replaced with the empty string.  Use $$ to
+// produce a literal dollar sign.  When the values of keys refer to each other in a
+// cycle, loading the store fails with an error that names the keys in the cycle.
+func WithLiteralKeys(names ...string) Option {
+	return optionFunc(func(c *Config) {
+		c.addLiteralKeys(names...)
+	})
+}
+
+// addLiteralKeys adds the patterns for keys which are not interpolated unless
+// they were already added
+func (c *Config) addLiteralKeys(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range names {
+		if !slices.Contains(c.literalKeys, name) {
+			c.literalKeys = append(c.literalKeys, name)
+		}
+	}
+}
+
+// interpolatedStore interpolates the values of a store when they are read.
+// The interpolated text of a value is converted in the same way as Values.
+type interpolatedStore struct {
+	Store
+	ctx       context.Context
+	isLiteral func(string) bool
+}
+
+// interpolate wraps the store so that values are interpolated when they are read
+func (c *Config) interpolate(ctx context.Context, store Store) Store {
+	if store == nil {
+		return nil
+	}
+	return &interpolatedStore{
+		Store:     store,
+		ctx:       ctx,
+		isLiteral: c.isLiteralKey,
+	}
+}
+
+// isLiteralKey determines whether the key is not interpolated.  Secret values
+// are never interpolated.
+func (c *Config) isLiteralKey(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.secretKeys[name] {
+		return true
+	}
+	for _, pattern := range c.literalKeys {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup gets the lookup which provides the value with the given name.  When the
+// value is interpolated, this is the interpolated text; otherwise, it is the store.
+func (s *interpolatedStore) lookup(name any) cli.Lookup {
+	key := nameToString(name)
+	if str, ok := s.interpolated(key); ok {
+		return Values{key: str}
+	}
+	return s.Store
+}
+
+// interpolated gets the interpolated text of the value, if it is a string which
+// is interpolated
+func (s *interpolatedStore) interpolated(name string) (string, bool) {
+	v, ok := s.Store.Interface(name)
+	str, isString := v.(string)
+	if !ok || !isString || !strings.Contains(str, "$") || s.isLiteral(name) {
+		return "", false
+	}
+	str, _ = s.resolve(name, nil)
+	return str, true
+}
+
+// checkCycles reports an error if the values of keys refer to each other in a
+// cycle.  Only the keys which can be enumerated are checked.
+func (s *interpolatedStore) checkCycles() error {
+	for key := range Keys(s.Store) {
+		if _, err := s.resolve(key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *interpolatedStore) Interface(name any) (any, bool) {
+	if str, ok := s.interpolated(nameToString(name)); ok {
+		return str, true
+	}
+	return s.Store.Interface(name)
+}
+
+func (s *interpolatedStore) Bool(name any) bool {
+	return s.lookup(name).Bool(name)
+}
+
+func (s *interpolatedStore) File(name any) *cli.File {
+	return s.lookup(name).File(name)
+}
+
+func (s *interpolatedStore) FileSet(name any) *cli.FileSet {
+	return s.lookup(name).FileSet(name)
+}
+
+func (s *interpolatedStore) Float32(name any) float32 {
+	return s.lookup(name).Float32(name)
+}
+
+func (s *interpolatedStore) Float64(name any) float64 {
+	return s.lookup(name).Float64(name)
+}
+
+func (s *interpolatedStore) Int(name any) int {
+	return s.lookup(name).Int(name)
+}
+
+func (s *interpolatedStore) Int16(name any) int16 {
+	return s.lookup(name).Int16(name)
+}
+
+func (s *interpolatedStore) Int32(name any) int32 {
+	return s.lookup(name).Int32(name)
+}
+
+func (s *interpolatedStore) Int64(name any) int64 {
+	return s.lookup(name).Int64(name)
+}
+
+func (s *interpolatedStore) Int8(name any) int8 {
+	return s.lookup(name).Int8(name)
+}
+
+func (s *interpolatedStore) Duration(name any) time.Duration {
+	return s.lookup(name).Duration(name)
+}
+
+func (s *interpolatedStore) Time(name any) time.Time {
+	return s.lookup(name).Time(name)
+}
+
+func (s *interpolatedStore) SemVer(name any) cli.Version {
+	return s.lookup(name).SemVer(name)
+}
+
+func (s *interpolatedStore) SemVerConstraint(name any) cli.VersionConstraint {
+	return s.lookup(name).SemVerConstraint(name)
+}
+
+func (s *interpolatedStore) List(name any) []string {
+	return s.lookup(name).List(name)
+}
+
+func (s *interpolatedStore) Map(name any) map[string]string {
+	return s.lookup(name).Map(name)
+}
+
+func (s *interpolatedStore) NameValue(name any) *cli.NameValue {
+	return s.lookup(name).NameValue(name)
+}
+
+func (s *interpolatedStore) NameValues(name any) []*cli.NameValue {
+	return s.lookup(name).NameValues(name)
+}
+
+func (s *interpolatedStore) String(name any) string {
+	return s.lookup(name).String(name)
+}
+
+func (s *interpolatedStore) Uint(name any) uint {
+	return s.lookup(name).Uint(name)
+}
+
+func (s *interpolatedStore) Uint16(name any) uint16 {
+	return s.lookup(name).Uint16(name)
+}
+
+func (s *interpolatedStore) Uint32(name any) uint32 {
+	return s.lookup(name).Uint32(name)
+}
+
+func (s *interpolatedStore) Uint64(name any) uint64 {
+	return s.lookup(name).Uint64(name)
+}
+
+func (s *interpolatedStore) Uint8(name any) uint8 {
+	return s.lookup(name).Uint8(name)
+}
+
+func (s *interpolatedStore) Value(name any) any {
+	return s.lookup(name).Value(name)
+}
+
+func (s *interpolatedStore) URL(name any) *url.URL {
+	return s.lookup(name).URL(name)
+}
+
+func (s *interpolatedStore) Regexp(name any) *regexp.Regexp {
+	return s.lookup(name).Regexp(name)
+}
+
+func (s *interpolatedStore) IP(name any) net.IP {
+	return s.lookup(name).IP(name)
+}
+
+func (s *interpolatedStore) IPNet(name any) netip.Prefix {
+	return s.lookup(name).IPNet(name)
+}
+
+func (s *interpolatedStore) Addr(name any) netip.Addr {
+	return s.lookup(name).Addr(name)
+}
+
+func (s *interpolatedStore) AddrPort(name any) netip.AddrPort {
+	return s.lookup(name).AddrPort(name)
+}
+
+func (s *interpolatedStore) HostPort(name any) string {
+	return s.lookup(name).HostPort(name)
+}
+
+func (s *interpolatedStore) HardwareAddr(name any) net.HardwareAddr {
+	return s.lookup(name).HardwareAddr(name)
+}
+
+func (s *interpolatedStore) IPRange(name any) *cli.IPRangeValue {
+	return s.lookup(name).IPRange(name)
+}
+
+func (s *interpolatedStore) ByteSize(name any) int64 {
+	return s.lookup(name).ByteSize(name)
+}
+
+func (s *interpolatedStore) Percent(name any) float64 {
+	return s.lookup(name).Percent(name)
+}
+
+func (s *interpolatedStore) SI(name any) float64 {
+	return s.lookup(name).SI(name)
+}
+
+func (s *interpolatedStore) Rate(name any) *cli.Rate {
+	return s.lookup(name).Rate(name)
+}
+
+func (s *interpolatedStore) BigInt(name any) *big.Int {
+	return s.lookup(name).BigInt(name)
+}
+
+func (s *interpolatedStore) BigFloat(name any) *big.Float {
+	return s.lookup(name).BigFloat(name)
+}
+
+func (s *interpolatedStore) Bytes(name any) []byte {
+	return s.lookup(name).Bytes(name)
+}
+
+func (s *interpolatedStore) Keys() iter.Seq[string] {
+	return Keys(s.Store)
+}
+
+func (s *interpolatedStore) Layer(name any) (Layer, bool) {
+	if f, ok := s.Store.(LayerFinder); ok {
+		return f.Layer(name)
+	}
+	return 0, false
+}
+
+func (s *interpolatedStore) Position(name any) (Position, bool) {
+	return findPosition(s.Store, nameToString(name))
+}
+
+func (s *interpolatedStore) Reload(ctx context.Context) error {
+	if rs, ok := s.Store.(ReloadableStore); ok {
+		return rs.Reload(ctx)
+	}
+	return fmt.Errorf("config does not support reloading")
+}
+
+// resolve gets the interpolated text of the key.  Visiting contains the keys
+// which are being interpolated in order to detect cycles.  The reference which
+// completes a cycle is replaced with the empty string and reported as an error.
+func (s *interpolatedStore) resolve(name string, visiting []string) (string, error) {
+	v, _ := s.Store.Interface(name)
+	str, isString := v.(string)
+	if !isString || !strings.Contains(str, "$") || s.isLiteral(name) {
+		return fmt.Sprint(v), nil
+	}
+	visiting = append(visiting, name)
+
+	var err error
+	vars := expander.Compose(
+		expander.Func(func(k string) any {
+			if i := slices.Index(visiting, k); i >= 0 {
+				if err == nil {
+					err = fmt.Errorf("config values refer to each other in a cycle: %s", strings.Join(append(visiting[i:], k), " -> "))
+				}
+				return nil
+			}
+			if !s.Store.Has(k) {
+				return nil
+			}
+			res, resolveErr := s.resolve(k, visiting)
+			if err == nil {
+				err = resolveErr
+			}
+			return res
+		}),
+		expander.Func(func(k string) any {
+			if v, ok := lookupSpecialVar(s.ctx, k); ok {
+				return v
+			}
+			if v, ok := lookupEnv(s.ctx, k); ok {
+				return v
+			}
+			return nil
+		}),
+	)
+
+	res := os.Expand(str, func(k string) string {
+		// $$ is an escaped dollar sign
+		if k == "$" {
+			return "$"
+		}
+		if v := vars.Expand(k); v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	})
+	return res, err
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"testing/fstest"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolation", func() {
+
+	var load = func(store config.Store, opts ...config.Option) (config.Store, error) {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Uses: cli.Pipeline(
+				config.NewWorkspace(
+					config.WithFS(fstest.MapFS{".env": {Data: []byte("INTERPOLATE_TEST_DOTENV=dotenv")}}),
+					config.WithDotEnv(".env"),
+				),
+				config.New(append(opts, config.WithStore(store))...),
+			),
+			Action: act,
+		}
+		if err := app.RunContext(context.Background(), []string{"app"}); err != nil {
+			return nil, err
+		}
+		return config.FromContext(act.ExecuteArgsForCall(0)).Store(), nil
+	}
+
+	DescribeTable("examples",
+		func(value string, expected string) {
+			GinkgoT().Setenv("INTERPOLATE_TEST_HOME", "/home/test")
+			store, err := load(config.Values{
+				"server.host": "example.com",
+				"server.port": "80",
+				"value":       value,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.String("value")).To(Equal(expected))
+		},
+		Entry("config keys", "${server.host}:${server.port}", "example.com:80"),
+		Entry("environment", "${INTERPOLATE_TEST_HOME}/bin", "/home/test/bin"),
+		Entry("unbraced environment", "$INTERPOLATE_TEST_HOME/bin", "/home/test/bin"),
+		Entry("dotenv", "${INTERPOLATE_TEST_DOTENV}", "dotenv"),
+		Entry("special variable", "${cli:app}", "app"),
+		Entry("escaped", "$${server.host}", "${server.host}"),
+		Entry("unresolved", "[${INTERPOLATE_TEST_UNSET}]", "[]"),
+	)
+
+	It("interpolates references which are interpolated", func() {
+		store, err := load(config.Values{
+			"a": "${b}/a",
+			"b": "${c}/b",
+			"c": "c",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.String("a")).To(Equal("c/b/a"))
+	})
+
+	It("converts interpolated values", func() {
+		store, err := load(config.Values{
+			"default.port": "8080",
+			"server.port":  "${default.port}",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Int("server.port")).To(Equal(8080))
+	})
+
+	It("reports an error naming the keys in a cycle", func() {
+		_, err := load(config.Values{
+			"a": "a${b}",
+			"b": "b${c}",
+			"c": "c${a}",
+		})
+		Expect(err).To(MatchError("config values refer to each other in a cycle: a -> b -> c -> a"))
+	})
+
+	It("interpolates values when they are read", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Uses: config.New(config.WithStore(config.Values{
+				"path": "${INTERPOLATE_TEST_LAZY}/bin",
+			})),
+			Action: act,
+		}
+		Expect(app.RunContext(context.Background(), []string{"app"})).To(Succeed())
+		store := config.FromContext(act.ExecuteArgsForCall(0)).Store()
+
+		GinkgoT().Setenv("INTERPOLATE_TEST_LAZY", "/opt")
+		Expect(store.String("path")).To(Equal("/opt/bin"))
+		Expect(store.Value("path")).To(Equal("/opt/bin"))
+	})
+
+	It("preserves the layer of the value", func() {
+		store, err := load(config.NewLayeredValues(map[config.Layer]config.Values{
+			config.LayerUser:      {"name": "user"},
+			config.LayerWorkspace: {"greeting": "hello ${name}"},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.String("greeting")).To(Equal("hello user"))
+
+		layer, _ := store.(config.LayerFinder).Layer("greeting")
+		Expect(layer).To(Equal(config.LayerWorkspace))
+	})
+
+	It("does not interpolate literal keys", func() {
+		store, err := load(config.Values{
+			"name":     "world",
+			"alias.co": "!git checkout $1 ${name}",
+			"greeting": "hello ${name}",
+		}, config.WithLiteralKeys("alias.*"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.String("alias.co")).To(Equal("!git checkout $1 ${name}"))
+		Expect(store.String("greeting")).To(Equal("hello world"))
+	})
+})
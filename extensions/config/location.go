@@ -97,6 +97,7 @@ func (l Layer) String() string {
 //   - cli:cache - the user cache directory for the app
 //   - cli:workspace - the workspace directory
 //   - cli:workspace.config - the workspace ConfigDir directory
+//   - cli:workspace.root - the outermost workspace directory (see Workspace.Root)
 //   - GOOS - populated from runtime.GOOS
 //   - GOARCH - populated from runtime.GOARCH
 //
@@ -112,6 +113,69 @@ func ParseLocation(s string) Location {
 	return &fileLocation{path: s}
 }
 
+// LayerLocation creates an idiomatic location for the file which stores the values of
+// the given layer.  The path can contain the same variables as ParseLocation, such as
+// ${cli:workspace.config} or ${cli:app}.  The format of the file is determined by its
+// extension:  .json, .yaml, .yml, and .toml use the corresponding marshal codec,
+// which must be available.  The location supports every operating system and
+// architecture.
+func LayerLocation(layer Layer, path string) IdiomaticLocation {
+	return &layerLocation{fileLocation: fileLocation{path: path}, layer: layer}
+}
+
+// Locations combines idiomatic locations into a location provider.  The paths of the
+// locations are enumerated in the order they are specified.  The locations do not
+// detect profiles, so the profile names are those defined in the store.  By default,
+// when the provider is set using WithLocation, the files of the locations are loaded
+// into the store, and a value in a higher layer takes precedence (see LayeredValues).
+//
+//	config.WithLocation(config.Locations(
+//		config.LayerLocation(config.LayerUser, "${HOME}/.config/${cli:app}/config.json"),
+//		config.LayerLocation(config.LayerWorkspace, "${cli:workspace.config}/config.json"),
+//	))
+func Locations(locations ...IdiomaticLocation) IdiomaticLocationProvider {
+	return idiomaticLocations(locations)
+}
+
+type idiomaticLocations []IdiomaticLocation
+
+type layerLocation struct {
+	fileLocation
+	layer Layer
+}
+
+func (l idiomaticLocations) Paths(ctx context.Context) ([]string, error) {
+	var res []string
+	for _, loc := range l {
+		paths, err := loc.Paths(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, paths...)
+	}
+	return res, nil
+}
+
+func (l idiomaticLocations) Resolve(Options) ([]IdiomaticLocation, error) {
+	return l, nil
+}
+
+func (idiomaticLocations) FindProfileNames(context.Context) []string {
+	return nil
+}
+
+func (l *layerLocation) Layer() Layer {
+	return l.layer
+}
+
+func (*layerLocation) OS() string {
+	return ""
+}
+
+func (*layerLocation) Arch() string {
+	return ""
+}
+
 type fileLocation struct {
 	path string
 }
@@ -177,46 +241,68 @@ func (dt *directoryTreeLocation) Paths(ctx context.Context) ([]string, error) {
 func expandPath(ctx context.Context, path string) (string, bool) {
 	allResolved := true
 	expanded := os.Expand(path, func(varName string) string {
-		switch varName {
-		case "GOOS":
-			return runtime.GOOS
-		case "GOARCH":
-			return runtime.GOARCH
-		case "cli:app":
-			name := appName(ctx)
-			allResolved = allResolved && (name != "")
-			return name
-		case "cli:cache":
-			cacheDir, err := os.UserCacheDir()
-			allResolved = allResolved && (err == nil)
-			if err != nil {
-				return ""
-			}
-			return filepath.Join(cacheDir, appName(ctx))
-
-		case "cli:workspace":
-			ws, _ := tryWorkspaceFromContext(ctx)
-			allResolved = allResolved && (ws != nil && ws.Dir() != "")
-			if ws == nil {
-				return ""
-			}
-			return ws.Dir()
-
-		case "cli:workspace.config":
-			ws, _ := tryWorkspaceFromContext(ctx)
-			allResolved = allResolved && (ws != nil && ws.ConfigDir() != "")
-			if ws == nil {
-				return ""
-			}
-			return ws.ConfigDir()
-		default:
-			if val, ok := os.LookupEnv(varName); ok {
-				return val
-			}
-			allResolved = false
-			return ""
+		if val, ok := lookupSpecialVar(ctx, varName); ok {
+			return val
 		}
+		val, ok := lookupEnv(ctx, varName)
+		allResolved = allResolved && ok && !isSpecialVar(varName)
+		return val
 	})
 
 	return expanded, allResolved
 }
+
+// lookupSpecialVar gets the value of one of the special variables supported
+// by ParseLocation.  False is returned if the variable can't be resolved.
+func lookupSpecialVar(ctx context.Context, varName string) (string, bool) {
+	switch varName {
+	case "GOOS":
+		return runtime.GOOS, true
+	case "GOARCH":
+		return runtime.GOARCH, true
+	case "cli:app":
+		name := appName(ctx)
+		return name, name != ""
+	case "cli:cache":
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", false
+		}
+		return filepath.Join(cacheDir, appName(ctx)), true
+
+	case "cli:workspace":
+		ws, _ := tryWorkspaceFromContext(ctx)
+		if ws == nil {
+			return "", false
+		}
+		return ws.Dir(), ws.Dir() != ""
+
+	case "cli:workspace.config":
+		ws, _ := tryWorkspaceFromContext(ctx)
+		if ws == nil {
+			return "", false
+		}
+		return ws.ConfigDir(), ws.ConfigDir() != ""
+
+	case "cli:workspace.root":
+		ws, _ := tryWorkspaceFromContext(ctx)
+		if ws == nil {
+			return "", false
+		}
+		return ws.Root(), ws.Root() != ""
+	}
+	return "", false
+}
+
+func isSpecialVar(varName string) bool {
+	return strings.HasPrefix(varName, "cli:")
+}
+
+// lookupEnv looks up the environment variable using the workspace, which
+// includes the variables from dotenv files
+func lookupEnv(ctx context.Context, varName string) (string, bool) {
+	if ws, _ := tryWorkspaceFromContext(ctx); ws != nil {
+		return ws.LookupEnv(varName)
+	}
+	return os.LookupEnv(varName)
+}
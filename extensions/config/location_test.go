@@ -239,6 +239,25 @@ var _ = Describe("ParseLocation", func() {
 	})
 })
 
+var _ = Describe("Locations", func() {
+
+	It("provides the paths of each layer location", func() {
+		GinkgoT().Setenv("TEST_VAR", "/expanded")
+		loc := config.Locations(
+			config.LayerLocation(config.LayerUser, "$TEST_VAR/user.json"),
+			config.LayerLocation(config.LayerWorkspace, "$NONEXISTENT_VAR/workspace.json"),
+		)
+
+		paths, err := loc.Paths(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{"/expanded/user.json"}))
+
+		resolved, _ := loc.Resolve(config.Options{})
+		Expect(resolved).To(HaveLen(2))
+		Expect(resolved[1].Layer()).To(Equal(config.LayerWorkspace))
+	})
+})
+
 type testWorkspaceFinder string
 
 func (t testWorkspaceFinder) FindWorkspacePath(_ context.Context, _ fs.FS, _ string) (string, error) {
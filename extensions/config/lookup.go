@@ -6,10 +6,16 @@ package config
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
 	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"time"
 
@@ -296,6 +302,30 @@ func (v Values) Duration(k any) time.Duration {
 	return p
 }
 
+func (v Values) Time(k any) time.Time {
+	t := convertValue[cli.TimeValue](v, k)
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Value()
+}
+
+func (v Values) SemVer(k any) cli.Version {
+	res := convertValue[cli.SemVerValue](v, k)
+	if res == nil {
+		return cli.Version{}
+	}
+	return res.Value()
+}
+
+func (v Values) SemVerConstraint(k any) cli.VersionConstraint {
+	res := convertValue[cli.SemVerConstraintValue](v, k)
+	if res == nil {
+		return cli.VersionConstraint{}
+	}
+	return res.Value()
+}
+
 func (v Values) URL(k any) *url.URL {
 	key := nameToString(k)
 	value, ok := v[key]
@@ -315,6 +345,78 @@ func (v Values) IP(k any) net.IP {
 	return net.ParseIP(value)
 }
 
+func (v Values) IPNet(k any) netip.Prefix {
+	res := convertValue[cli.IPNetValue](v, k)
+	if res == nil {
+		return netip.Prefix{}
+	}
+	return res.Value()
+}
+
+func (v Values) Addr(k any) netip.Addr {
+	res := convertValue[cli.AddrValue](v, k)
+	if res == nil {
+		return netip.Addr{}
+	}
+	return res.Value()
+}
+
+func (v Values) AddrPort(k any) netip.AddrPort {
+	res := convertValue[cli.AddrPortValue](v, k)
+	if res == nil {
+		return netip.AddrPort{}
+	}
+	return res.Value()
+}
+
+func (v Values) HostPort(k any) string {
+	res := convertValue[cli.HostPortValue](v, k)
+	if res == nil {
+		return ""
+	}
+	return res.Value()
+}
+
+func (v Values) HardwareAddr(k any) net.HardwareAddr {
+	res := convertValue[cli.HardwareAddrValue](v, k)
+	if res == nil {
+		return nil
+	}
+	return res.Value()
+}
+
+func (v Values) IPRange(k any) *cli.IPRangeValue {
+	return convertValue[cli.IPRangeValue](v, k)
+}
+
+func (v Values) ByteSize(k any) int64 {
+	res := convertValue[cli.ByteSizeValue](v, k)
+	if res == nil {
+		return 0
+	}
+	return res.Value()
+}
+
+func (v Values) Percent(k any) float64 {
+	res := convertValue[cli.PercentValue](v, k)
+	if res == nil {
+		return 0
+	}
+	return res.Value()
+}
+
+func (v Values) SI(k any) float64 {
+	res := convertValue[cli.SIValue](v, k)
+	if res == nil {
+		return 0
+	}
+	return res.Value()
+}
+
+func (v Values) Rate(k any) *cli.Rate {
+	return convertValue[cli.Rate](v, k)
+}
+
 func (v Values) Regexp(k any) *regexp.Regexp {
 	key := nameToString(k)
 	value, ok := v[key]
@@ -359,6 +461,11 @@ func (v Values) Has(k any) bool {
 	return ok
 }
 
+// Keys enumerates the names of the values in sorted order
+func (v Values) Keys() iter.Seq[string] {
+	return slices.Values(slices.Sorted(maps.Keys(v)))
+}
+
 // Bool obtains the value and converts it to a bool
 func (c *Config) Bool(name any) bool {
 	return c.Store().Bool(name)
@@ -439,6 +546,21 @@ func (c *Config) Duration(name any) time.Duration {
 	return c.Store().Duration(name)
 }
 
+// Time obtains the Time for the specified name
+func (c *Config) Time(name any) time.Time {
+	return c.Store().Time(name)
+}
+
+// SemVer obtains the Version for the specified name
+func (c *Config) SemVer(name any) cli.Version {
+	return c.Store().SemVer(name)
+}
+
+// SemVerConstraint obtains the VersionConstraint for the specified name
+func (c *Config) SemVerConstraint(name any) cli.VersionConstraint {
+	return c.Store().SemVerConstraint(name)
+}
+
 // File obtains the File for the specified name
 func (c *Config) File(name any) *cli.File {
 	return c.Store().File(name)
@@ -479,6 +601,56 @@ func (c *Config) IP(name any) net.IP {
 	return c.Store().IP(name)
 }
 
+// IPNet obtains the IPNet for the specified name
+func (c *Config) IPNet(name any) netip.Prefix {
+	return c.Store().IPNet(name)
+}
+
+// Addr obtains the Addr for the specified name
+func (c *Config) Addr(name any) netip.Addr {
+	return c.Store().Addr(name)
+}
+
+// AddrPort obtains the AddrPort for the specified name
+func (c *Config) AddrPort(name any) netip.AddrPort {
+	return c.Store().AddrPort(name)
+}
+
+// HostPort obtains the host and port for the specified name
+func (c *Config) HostPort(name any) string {
+	return c.Store().HostPort(name)
+}
+
+// HardwareAddr obtains the HardwareAddr for the specified name
+func (c *Config) HardwareAddr(name any) net.HardwareAddr {
+	return c.Store().HardwareAddr(name)
+}
+
+// IPRange obtains the IPRange for the specified name
+func (c *Config) IPRange(name any) *cli.IPRangeValue {
+	return c.Store().IPRange(name)
+}
+
+// ByteSize obtains the byte size for the specified name
+func (c *Config) ByteSize(name any) int64 {
+	return c.Store().ByteSize(name)
+}
+
+// Percent obtains the percentage for the specified name
+func (c *Config) Percent(name any) float64 {
+	return c.Store().Percent(name)
+}
+
+// SI obtains the number with an SI prefix for the specified name
+func (c *Config) SI(name any) float64 {
+	return c.Store().SI(name)
+}
+
+// Rate obtains the rate for the specified name
+func (c *Config) Rate(name any) *cli.Rate {
+	return c.Store().Rate(name)
+}
+
 // BigInt obtains the BigInt for the specified name
 func (c *Config) BigInt(name any) *big.Int {
 	return c.Store().BigInt(name)
@@ -509,5 +681,75 @@ func (c *Config) Has(name any) bool {
 	return c.Store().Has(name)
 }
 
+// Keys enumerates the names of the values in the configuration if the
+// store supports enumeration
+func (c *Config) Keys() iter.Seq[string] {
+	return Keys(c.Store())
+}
+
+// ListOf obtains the value for the specified name converted to a list of T
+// using the same conversion as cli.ListOf.  Text values are split on commas.
+// If the value is missing or cannot be converted, nil is returned.
+func ListOf[T any](s Store, name any) []T {
+	return convertCollection(s, name, cli.ListOf[T]())
+}
+
+// SetOf obtains the value for the specified name converted to a set of T
+// using the same conversion as cli.SetOf.  Text values are split on commas.
+// If the value is missing or cannot be converted, nil is returned.
+func SetOf[T comparable](s Store, name any) []T {
+	return convertCollection(s, name, cli.SetOf[T]())
+}
+
+// MapOf obtains the value for the specified name converted to a map
+// using the same conversion as cli.MapOf.  Text values use the syntax
+// key=value separated by commas.  If the value is missing or cannot be
+// converted, nil is returned.
+func MapOf[K comparable, V any](s Store, name any) map[K]V {
+	return convertCollection(s, name, cli.MapOf[K, V]())
+}
+
+func convertCollection[T any](s Store, name any, dest interface {
+	cli.Value
+	Value() T
+	DisableSplitting()
+}) T {
+	var zero T
+	var err error
+	value := s.Value(name)
+	switch value.(type) {
+	case []string, []any, map[string]any:
+		// Structured values provide the elements, so they are not split
+		dest.DisableSplitting()
+	}
+
+	switch v := value.(type) {
+	case T:
+		return v
+	case string:
+		err = dest.Set(v)
+	case []string:
+		for _, item := range v {
+			err = errors.Join(err, dest.Set(item))
+		}
+	case []any:
+		for _, item := range v {
+			err = errors.Join(err, dest.Set(fmt.Sprint(item)))
+		}
+	case map[string]any:
+		for k, item := range v {
+			err = errors.Join(err, dest.Set(k+"="+fmt.Sprint(item)))
+		}
+	default:
+		return zero
+	}
+	if err != nil {
+		return zero
+	}
+	return dest.Value()
+}
+
 var _ Store = (*Config)(nil)
 var _ Store = (Values)(nil)
+var _ KeyLister = (*Config)(nil)
+var _ KeyLister = (Values)(nil)
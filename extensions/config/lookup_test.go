@@ -5,9 +5,12 @@
 package config_test
 
 import (
+	"fmt"
 	"net"
+	"net/netip"
 	"net/url"
 	"regexp"
+	"time"
 
 	"github.com/Carbonfrost/joe-cli/extensions/config"
 	. "github.com/onsi/ginkgo/v2"
@@ -138,6 +141,108 @@ var _ = Describe("Values", func() {
 				"127.0.0.1",
 				Equal(net.ParseIP("127.0.0.1")),
 			),
+			Entry(
+				"IPNet",
+				func(lv config.Values, k any) any { return lv.IPNet(k) },
+				"10.0.0.0/8",
+				Equal(netip.MustParsePrefix("10.0.0.0/8")),
+			),
+			Entry(
+				"Addr",
+				func(lv config.Values, k any) any { return lv.Addr(k) },
+				"::1",
+				Equal(netip.IPv6Loopback()),
+			),
+			Entry(
+				"AddrPort",
+				func(lv config.Values, k any) any { return lv.AddrPort(k) },
+				"10.0.0.1:80",
+				Equal(netip.MustParseAddrPort("10.0.0.1:80")),
+			),
+			Entry(
+				"HostPort",
+				func(lv config.Values, k any) any { return lv.HostPort(k) },
+				"example.com:8080",
+				Equal("example.com:8080"),
+			),
+			Entry(
+				"HardwareAddr",
+				func(lv config.Values, k any) any { return lv.HardwareAddr(k) },
+				"00:00:5e:00:53:01",
+				Equal(net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01}),
+			),
+			Entry(
+				"SemVer",
+				func(lv config.Values, k any) any { return lv.SemVer(k) },
+				"v1.2.3",
+				WithTransform(func(v any) string { return fmt.Sprint(v) }, Equal("1.2.3")),
+			),
+			Entry(
+				"SemVerConstraint",
+				func(lv config.Values, k any) any { return lv.SemVerConstraint(k).String() },
+				">=1.2 <2",
+				Equal(">=1.2 <2"),
+			),
+			Entry(
+				"IPRange",
+				func(lv config.Values, k any) any { return lv.IPRange(k).String() },
+				"10.0.0.1-10.0.0.20",
+				Equal("10.0.0.1-10.0.0.20"),
+			),
+			Entry(
+				"ByteSize",
+				func(lv config.Values, k any) any { return lv.ByteSize(k) },
+				"1.5KiB",
+				Equal(int64(1536)),
+			),
+			Entry(
+				"Percent",
+				func(lv config.Values, k any) any { return lv.Percent(k) },
+				"50%",
+				Equal(0.5),
+			),
+			Entry(
+				"SI",
+				func(lv config.Values, k any) any { return lv.SI(k) },
+				"2k",
+				Equal(2000.0),
+			),
+			Entry(
+				"Rate",
+				func(lv config.Values, k any) any { return lv.Rate(k).PerSecond() },
+				"120/min",
+				Equal(2.0),
+			),
+			Entry(
+				"Time",
+				func(lv config.Values, k any) any { return lv.Time(k) },
+				"2024-03-01T10:30:00Z",
+				Equal(time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)),
+			),
+			Entry(
+				"ListOf",
+				func(lv config.Values, k any) any { return config.ListOf[int](lv, k) },
+				"1,2,2",
+				Equal([]int{1, 2, 2}),
+			),
+			Entry(
+				"SetOf",
+				func(lv config.Values, k any) any { return config.SetOf[int](lv, k) },
+				"1,2,2",
+				Equal([]int{1, 2}),
+			),
+			Entry(
+				"MapOf",
+				func(lv config.Values, k any) any { return config.MapOf[string, int](lv, k) },
+				"a=1,b=2",
+				Equal(map[string]int{"a": 1, "b": 2}),
+			),
+			Entry(
+				"ListOf invalid",
+				func(lv config.Values, k any) any { return config.ListOf[int](lv, k) },
+				"1,x",
+				BeNil(),
+			),
 		)
 	})
 })
@@ -0,0 +1,212 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+)
+
+const (
+	profilePrefix = "profile."
+	extendsKey    = "extends"
+)
+
+// Profiles gets the names of the profiles which were selected.  More than one
+// profile can be selected using a comma-separated list such as a,b, in which
+// case the values from b take precedence over those from a.
+func (c *Config) Profiles() []string {
+	return splitNames(c.options.Profile)
+}
+
+// ProfileNames gets the names of the profiles which are defined in the store.
+// A profile is defined by values named profile.NAME.KEY, which typically are
+// written as sections in a configuration file:
+//
+//	[profile.staging]
+//	extends = base
+//	server.url = https://staging.example.com
+func ProfileNames(store Store) []string {
+	names := map[string]bool{}
+	for key := range Keys(store) {
+		if name, _, ok := cutProfileKey(key); ok {
+			names[name] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// ResolveProfiles gets the profiles in the order that their values are merged
+// for the given profile names.  Each profile is preceded by the profiles that it
+// extends in the order that they are listed in its extends value, and each
+// profile occurs once at its earliest position.  An error is returned if a profile
+// is not defined or the profiles extend each other in a cycle.
+func ResolveProfiles(store Store, names ...string) ([]string, error) {
+	var (
+		res      []string
+		visiting []string
+		visit    func(name string) error
+	)
+	defined := ProfileNames(store)
+
+	visit = func(name string) error {
+		if slices.Contains(res, name) {
+			return nil
+		}
+		if i := slices.Index(visiting, name); i >= 0 {
+			return fmt.Errorf("profiles extend each other in a cycle: %s", strings.Join(append(visiting[i:], name), " -> "))
+		}
+		if !slices.Contains(defined, name) {
+			return fmt.Errorf("profile not found: %s", name)
+		}
+
+		visiting = append(visiting, name)
+		for _, base := range splitNames(store.String(profilePrefix + name + "." + extendsKey)) {
+			if err := visit(base); err != nil {
+				return err
+			}
+		}
+		visiting = visiting[:len(visiting)-1]
+		res = append(res, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ProfileValues gets the effective values of the given profiles, which
+// merges the values of each profile and the profiles it extends.  The names of
+// the values are not qualified by the profile.
+func ProfileValues(store Store, names ...string) (Values, error) {
+	values, _, err := profileValues(store, names)
+	return values, err
+}
+
+func profileValues(store Store, names []string) (Values, map[string]string, error) {
+	order, err := ResolveProfiles(store, names...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := Values{}
+	origins := map[string]string{}
+	for _, name := range order {
+		prefix := profilePrefix + name + "."
+		for key := range Keys(store) {
+			short, ok := strings.CutPrefix(key, prefix)
+			if !ok || short == extendsKey {
+				continue
+			}
+			res[short] = store.String(key)
+			origins[short] = key
+		}
+	}
+	return res, origins, nil
+}
+
+// applyProfiles merges the values of the selected profiles into the store in
+// the profile layer.  Stores which don't define any profiles are left alone
+// because the profile could be handled by the loader.
+func (c *Config) applyProfiles(store Store) (Store, error) {
+	names := c.Profiles()
+	if len(names) == 0 || len(ProfileNames(store)) == 0 {
+		return store, nil
+	}
+
+	values, origins, err := profileValues(store, names)
+	if err != nil {
+		return nil, err
+	}
+	res := mergeValues(store, values, LayerProfile, true)
+	if l, ok := res.(*LayeredValues); ok {
+		for short, key := range origins {
+			if pos, ok := findPosition(store, key); ok {
+				l.positions[short] = pos
+			}
+		}
+	}
+	return res, nil
+}
+
+// mergeValues combines values with the store.  When override is set, the values take
+// precedence over the store; otherwise, they are only used when the store does not
+// have the value.  When the store is LayeredValues, the values are in the given layer.
+func mergeValues(store Store, values Values, layer Layer, override bool) Store {
+	values = maps.Clone(values)
+	if !override {
+		maps.DeleteFunc(values, func(k, _ string) bool {
+			return store.Has(k)
+		})
+	}
+	if len(values) == 0 {
+		return store
+	}
+
+	switch st := store.(type) {
+	case *LayeredValues:
+		res := &LayeredValues{
+			Values:    maps.Clone(st.Values),
+			layers:    maps.Clone(st.layers),
+			positions: maps.Clone(st.positions),
+		}
+		for k, v := range values {
+			res.Values[k] = v
+			res.layers[k] = layer
+			delete(res.positions, k)
+		}
+		return res
+
+	case Values:
+		res := maps.Clone(st)
+		maps.Copy(res, values)
+		return res
+	}
+
+	return wrapperStore{
+		Lookup: cli.LookupFunc(func(name string) (any, bool) {
+			if v, ok := values[name]; ok {
+				return v, true
+			}
+			return store.Interface(name)
+		}),
+		has: func(name string) bool {
+			return values.Has(name) || store.Has(name)
+		},
+		keys: func() iter.Seq[string] {
+			all := slices.AppendSeq(slices.Collect(Keys(store)), maps.Keys(values))
+			slices.Sort(all)
+			return slices.Values(slices.Compact(all))
+		},
+	}
+}
+
+func cutProfileKey(key string) (name, rest string, ok bool) {
+	key, ok = strings.CutPrefix(key, profilePrefix)
+	if !ok {
+		return "", "", false
+	}
+	name, rest, ok = strings.Cut(key, ".")
+	return name, rest, ok && name != ""
+}
+
+func splitNames(s string) []string {
+	var res []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			res = append(res, name)
+		}
+	}
+	return res
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+
+	var store = config.NewLayeredValues(map[config.Layer]config.Values{
+		config.LayerUser: {
+			"region":                         "us",
+			"replicas":                       "1",
+			"profile.base.replicas":          "2",
+			"profile.base.log":               "info",
+			"profile.staging.extends":        "base",
+			"profile.staging.log":            "debug",
+			"profile.eu.extends":             "base",
+			"profile.eu.region":              "eu",
+			"profile.staging-eu.extends":     "staging, eu",
+			"profile.staging-eu.replicas":    "3",
+			"profile.loop.extends":           "cycle",
+			"profile.cycle.extends":          "loop",
+			"profile.missing-parent.extends": "nope",
+		},
+	})
+
+	DescribeTable("ResolveProfiles",
+		func(names []string, expected any) {
+			actual, err := config.ResolveProfiles(store, names...)
+			if s, ok := expected.(string); ok {
+				Expect(err).To(MatchError(s))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("base first", []string{"staging"}, []string{"base", "staging"}),
+		Entry("shared base once", []string{"staging-eu"}, []string{"base", "staging", "eu", "staging-eu"}),
+		Entry("stacked", []string{"eu", "staging"}, []string{"base", "eu", "staging"}),
+		Entry("cycle", []string{"loop"}, "profiles extend each other in a cycle: loop -> cycle -> loop"),
+		Entry("not found", []string{"missing-parent"}, "profile not found: nope"),
+	)
+
+	It("gets the effective values of a profile", func() {
+		values, err := config.ProfileValues(store, "staging-eu")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(config.Values{
+			"replicas": "3",
+			"log":      "debug",
+			"region":   "eu",
+		}))
+	})
+
+	It("lists the profile names", func() {
+		Expect(config.ProfileNames(store)).To(ContainElements("base", "eu", "staging", "staging-eu"))
+	})
+
+	Describe("selecting profiles", func() {
+
+		var run = func(arguments string) (config.Store, error) {
+			act := new(joeclifakes.FakeAction)
+			app := &cli.App{
+				Name:   "app",
+				Uses:   config.New(config.WithStore(store)),
+				Action: act,
+			}
+			args, _ := cli.Split(arguments)
+			if err := app.RunContext(context.Background(), args); err != nil {
+				return nil, err
+			}
+			return config.FromContext(act.ExecuteArgsForCall(0)).Store(), nil
+		}
+
+		It("merges the profiles into the profile layer", func() {
+			actual, err := run("app --profile eu,staging")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String("region")).To(Equal("eu"))
+			Expect(actual.String("log")).To(Equal("debug"))
+			Expect(actual.String("replicas")).To(Equal("2"))
+
+			layer, _ := actual.(config.LayerFinder).Layer("region")
+			Expect(layer).To(Equal(config.LayerProfile))
+		})
+
+		It("provides values to flags bound to keys", func() {
+			act := new(joeclifakes.FakeAction)
+			app := &cli.App{
+				Name: "app",
+				Uses: config.New(config.WithStore(store)),
+				Flags: []*cli.Flag{
+					{Name: "region", Uses: config.ConfigKey("region")},
+				},
+				Action: act,
+			}
+			args, _ := cli.Split("app --profile eu")
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			Expect(cli.FromContext(act.ExecuteArgsForCall(0)).String("region")).To(Equal("eu"))
+		})
+
+		It("uses the base values without a profile", func() {
+			actual, err := run("app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String("region")).To(Equal("us"))
+		})
+
+		It("returns an error for an unknown profile", func() {
+			_, err := run("app --profile prod")
+			Expect(err).To(MatchError("profile not found: prod"))
+		})
+	})
+
+	It("prints the merge order of profiles", func() {
+		var stdout bytes.Buffer
+		app := &cli.App{
+			Name:   "app",
+			Stdout: &stdout,
+			Uses: cli.Pipeline(
+				config.New(config.WithStore(config.Values{
+					"profile.base.log":        "info",
+					"profile.staging.extends": "base",
+					"profile.staging.log":     "debug",
+				})),
+				config.Commands(),
+			),
+		}
+		args, _ := cli.Split("app config profiles")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(stdout.String()).To(Equal(
+			"PROFILE  MERGE ORDER\n" +
+				"base     base\n" +
+				"staging  base, staging\n",
+		))
+	})
+})
@@ -0,0 +1,214 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/marshal"
+)
+
+// Schema describes the keys of the configuration.  The map is indexed by the
+// qualified name of each key, such as server.port.
+type Schema map[string]SchemaKey
+
+// SchemaKey describes a key in the configuration schema
+type SchemaKey struct {
+	// Type is the type of the value, which is used to validate it.  A key whose type is
+	// Map also declares the keys which are qualified by its name, so that
+	// the key alias declares alias.co.  When the type is UnknownType, any value is valid.
+	Type marshal.BuiltinType
+
+	// Default provides the value which is used when no layer provides one
+	Default string
+
+	// Required indicates that some layer must provide the value
+	Required bool
+
+	// Enum lists the values which are allowed
+	Enum []string
+
+	// HelpText describes the key
+	HelpText string
+}
+
+// UnknownKey describes a key which is present in the configuration but
+// is not declared in the schema
+type UnknownKey struct {
+	// Name is the qualified name of the key
+	Name string
+
+	// Position is where the key is defined if it is known
+	Position Position
+
+	// Suggestions are the keys in the schema which have similar names
+	Suggestions []string
+}
+
+// NewSchema creates a schema from the types of the keys.  Nested schemas provide
+// keys which are qualified by the name of the field, so that a schema with the
+// field server that contains the field port provides the key server.port.
+func NewSchema(types marshal.Schema) Schema {
+	res := Schema{}
+	var flatten func(prefix string, types marshal.Schema)
+	flatten = func(prefix string, types marshal.Schema) {
+		for name, t := range types {
+			switch t := t.(type) {
+			case marshal.Schema:
+				flatten(prefix+name+".", t)
+			case marshal.BuiltinType:
+				res[prefix+name] = SchemaKey{Type: t}
+			}
+		}
+	}
+	flatten("", types)
+	return res
+}
+
+// WithSchema sets the schema of the configuration.  When the configuration is
+// loaded, the values are validated using the schema:  a required key which no layer
+// provides, a value which is not valid for the type of its key, and a value which is not
+// one of the allowed values are errors.  Errors indicate the file and line of the value
+// when the store provides positions (see PositionFinder).  The defaults from the schema are
+// used for values that no layer provides, and they are reported as being in the
+// intrinsic layer.  When the Load action is used, keys which are not in the schema are
+// reported as warnings on stderr.
+func WithSchema(s Schema) Option {
+	return optionFunc(func(c *Config) {
+		c.schema = s
+	})
+}
+
+// Schema gets the schema of the configuration, which can be nil
+func (c *Config) Schema() Schema {
+	return c.schema
+}
+
+// Validate checks that the values in the store are valid for the schema.
+// All errors are combined using errors.Join.
+func (s Schema) Validate(store Store) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(s)) {
+		key := s[name]
+		if !store.Has(name) {
+			if key.Required {
+				errs = append(errs, fmt.Errorf("missing required config key %s", name))
+			}
+			continue
+		}
+
+		value, _ := store.Interface(name)
+		if err := key.validate(fmt.Sprint(value)); err != nil {
+			prefix := ""
+			if pos, ok := findPosition(store, name); ok {
+				prefix = pos.String() + ": "
+			}
+			errs = append(errs, fmt.Errorf("%sinvalid value for %s: %w", prefix, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UnknownKeys gets the keys in the store which are not declared in the schema.
+// The store must be able to enumerate its keys (see KeyLister).
+func (s Schema) UnknownKeys(store Store) []UnknownKey {
+	var res []UnknownKey
+	names := slices.Sorted(maps.Keys(s))
+	for name := range Keys(store) {
+		if s.declares(name) {
+			continue
+		}
+		pos, _ := findPosition(store, name)
+		res = append(res, UnknownKey{
+			Name:        name,
+			Position:    pos,
+			Suggestions: cli.SuggestNames(name, names),
+		})
+	}
+	return res
+}
+
+// declares determines whether the key is in the schema, including keys
+// qualified by the name of a Map and the keys of profiles
+func (s Schema) declares(name string) bool {
+	if _, rest, ok := cutProfileKey(name); ok {
+		return rest == extendsKey || s.declares(rest)
+	}
+	if _, ok := s[name]; ok {
+		return true
+	}
+	for i := strings.LastIndex(name, "."); i > 0; i = strings.LastIndex(name, ".") {
+		name = name[:i]
+		if k, ok := s[name]; ok && k.Type == marshal.Map {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schema) withDefaults(store Store) Store {
+	defaults := Values{}
+	for name, key := range s {
+		if key.Default != "" {
+			defaults[name] = key.Default
+		}
+	}
+	return mergeValues(store, defaults, LayerIntrinsic, false)
+}
+
+func (k SchemaKey) validate(value string) error {
+	if len(k.Enum) > 0 && !slices.Contains(k.Enum, value) {
+		return fmt.Errorf("must be one of: %s", strings.Join(k.Enum, ", "))
+	}
+	if k.Type == marshal.UnknownType || k.Type == marshal.Enum {
+		return nil
+	}
+	return cli.Set(k.Type.New(), value)
+}
+
+// description gets the help text with the details of the key
+func (k SchemaKey) description() string {
+	var details []string
+	if k.Required {
+		details = append(details, "required")
+	}
+	if len(k.Enum) > 0 {
+		details = append(details, "one of: "+strings.Join(k.Enum, ", "))
+	}
+	if len(details) == 0 {
+		return k.HelpText
+	}
+	return strings.TrimSpace(k.HelpText + " (" + strings.Join(details, "; ") + ")")
+}
+
+func findPosition(store Store, name string) (Position, bool) {
+	if f, ok := store.(PositionFinder); ok {
+		return f.Position(name)
+	}
+	return Position{}, false
+}
+
+func (c *Config) warnUnknownKeys(ctx context.Context, store Store) {
+	cc, ok := cli.TryFromContext(ctx)
+	if !ok || cc == nil || c.schema == nil {
+		return
+	}
+	for _, u := range c.schema.UnknownKeys(store) {
+		msg := "warning: unknown config key " + u.Name
+		if u.Position.File != "" {
+			msg += " (" + u.Position.String() + ")"
+		}
+		if len(u.Suggestions) > 0 {
+			msg += "; did you mean " + u.Suggestions[0] + "?"
+		}
+		fmt.Fprintln(cc.Stderr, msg)
+	}
+}
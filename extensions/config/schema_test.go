@@ -0,0 +1,121 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	"github.com/Carbonfrost/joe-cli/extensions/marshal"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		stderr *bytes.Buffer
+
+		schema = config.Schema{
+			"server.port": {Type: config.Int, Default: "8080", HelpText: "The server port"},
+			"server.url":  {Type: config.URL, Required: true, HelpText: "The server URL"},
+			"log.level":   {Type: config.Enum, Enum: []string{"debug", "info"}, Default: "info"},
+			"alias":       {Type: config.Map, HelpText: "Command aliases"},
+		}
+
+		run = func(arguments string, act cli.Action) error {
+			app := &cli.App{
+				Name:   "app",
+				Stdout: stdout,
+				Stderr: stderr,
+				Uses: cli.Pipeline(
+					config.New(
+						config.WithLocation(config.Locations(
+							config.LayerLocation(config.LayerUser, filepath.Join(dir, "user.json")),
+							config.LayerLocation(config.LayerWorkspace, filepath.Join(dir, "workspace.json")),
+						)),
+						config.WithSchema(schema),
+					),
+					config.Commands(),
+				),
+				Action: act,
+			}
+			args, _ := cli.Split(arguments)
+			return app.RunContext(context.Background(), args)
+		}
+		writeFile = func(name, data string) {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(data), 0644)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+		writeFile("user.json", `{"server": {"url": "https://example.com"}}`)
+	})
+
+	It("provides defaults in the intrinsic layer", func() {
+		act := new(joeclifakes.FakeAction)
+		Expect(run("app", act)).To(Succeed())
+
+		store := config.FromContext(act.ExecuteArgsForCall(0)).Store()
+		Expect(store.Int("server.port")).To(Equal(8080))
+		layer, _ := store.(config.LayerFinder).Layer("server.port")
+		Expect(layer).To(Equal(config.LayerIntrinsic))
+	})
+
+	It("returns an error for a missing required key", func() {
+		writeFile("user.json", "{}")
+		Expect(run("app", nil)).To(MatchError("missing required config key server.url"))
+	})
+
+	It("returns an error with the file and line", func() {
+		writeFile("user.json", "{\n  \"server\": {\n    \"url\": \"https://example.com\",\n    \"port\": \"abc\"\n  }\n}\n")
+		Expect(run("app", nil)).To(MatchError(HavePrefix(filepath.Join(dir, "user.json") + ":4: invalid value for server.port:")))
+	})
+
+	It("returns an error with the approximate line in JSON", func() {
+		writeFile("workspace.json", "{\n  \"log\": {\n    \"level\": \"trace\"\n  }\n}\n")
+		Expect(run("app", nil)).To(MatchError(filepath.Join(dir, "workspace.json") + ":3: invalid value for log.level: must be one of: debug, info"))
+	})
+
+	It("warns about unknown keys with suggestions", func() {
+		writeFile("user.json", "{\n  \"server\": {\n    \"url\": \"https://example.com\",\n    \"prot\": 80\n  },\n  \"alias\": {\n    \"co\": \"checkout\"\n  }\n}\n")
+		Expect(run("app", new(joeclifakes.FakeAction))).To(Succeed())
+		Expect(stderr.String()).To(Equal(
+			"warning: unknown config key server.prot (" + filepath.Join(dir, "user.json") + ":4); did you mean server.port?\n",
+		))
+	})
+
+	It("prints the schema", func() {
+		Expect(run("app config schema", nil)).To(Succeed())
+		Expect(stdout.String()).To(Equal(
+			"KEY          TYPE  DEFAULT  DESCRIPTION\n" +
+				"alias        map            Command aliases\n" +
+				"log.level    enum  info     (one of: debug, info)\n" +
+				"server.port  int   8080     The server port\n" +
+				"server.url   url            The server URL (required)\n",
+		))
+	})
+
+	It("creates a schema from types", func() {
+		s := config.NewSchema(marshal.Schema{
+			"server": marshal.Schema{"port": marshal.Int},
+			"name":   marshal.String,
+		})
+		Expect(s).To(Equal(config.Schema{
+			"server.port": {Type: marshal.Int},
+			"name":        {Type: marshal.String},
+		}))
+	})
+})
@@ -0,0 +1,482 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"maps"
+	"strings"
+	"sync"
+
+	cli "github.com/Carbonfrost/joe-cli"
+)
+
+// SecretStore provides a config Store whose values are stored in a file encrypted
+// with AES-256-GCM.  The key is derived from a passphrase using PBKDF2 with SHA-256.
+// The file is JSON which contains the parameters of the key derivation and the
+// encrypted values, so the names of the values are not revealed.
+type SecretStore struct {
+	Values
+	header secretFile
+	key    []byte
+}
+
+// secretFile is the format of the encrypted file
+type secretFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce,omitempty"`
+	Data       []byte `json:"data,omitempty"`
+}
+
+const (
+	secretFileVersion = 1
+	secretKDF         = "pbkdf2-sha256"
+	secretIterations  = 600000
+)
+
+var errSecretsLocked = errors.New("secrets are locked: a passphrase is required (see WithSecretKeyFile)")
+
+// WithSecretFile sets the path to the encrypted file which stores secret values (see
+// SecretStore).  The path can contain the same variables as the paths of layer files,
+// and it typically is in the same directory as the file of the user layer:
+//
+//	config.WithSecretFile("${HOME}/.config/${cli:app}/secrets.json")
+//
+// When the file exists, its values are merged into the store in the user layer, so
+// they are read in the same way as other values, such as Config.String("github.token").
+// A value in the workspace or a higher layer takes precedence over a secret with the
+// same name.  When a key file was set with WithSecretKeyFile, the file is unlocked
+// when the configuration is loaded.  Otherwise, it is unlocked the first time that a
+// value which isn't in the other files is read, and the passphrase is prompted
+// using Context.ReadPasswordString once per process.  Checking whether a value
+// exists using Has doesn't unlock the file, so Has only reports secret values once
+// the file is unlocked; however, ConfigKey reads the secret file for a flag whose
+// value is a cli.SecretValue.  If the passphrase can't be prompted, such as when
+// there is no terminal, or it is incorrect, a warning is printed and no secret
+// values are provided.  The file is written with permissions which only allow the
+// user to read it.
+func WithSecretFile(path string) Option {
+	return optionFunc(func(c *Config) {
+		c.secretFile = path
+		if c.store.fn == nil {
+			c.store.fn = c.loadLocations
+		}
+	})
+}
+
+// WithSecretKeyFile sets the path to a file whose contents are used as the passphrase
+// of the secret file instead of prompting for it.  Trailing whitespace in the key file
+// is ignored.  The path can contain variables, which makes it possible to specify it
+// using an environment variable, such as ${APP_SECRET_KEY_FILE}.
+func WithSecretKeyFile(path string) Option {
+	return optionFunc(func(c *Config) {
+		c.secretKeyFile = path
+	})
+}
+
+// NewSecretStore creates an empty secret store, which is encrypted using a key
+// derived from the passphrase with a new random salt
+func NewSecretStore(passphrase []byte) (*SecretStore, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	header := secretFile{
+		Version:    secretFileVersion,
+		KDF:        secretKDF,
+		Iterations: secretIterations,
+		Salt:       salt,
+	}
+	key, err := header.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretStore{Values: Values{}, header: header, key: key}, nil
+}
+
+// ParseSecretStore decrypts the contents of a secret file using the passphrase.
+// An error is returned if the passphrase is incorrect.
+func ParseSecretStore(data, passphrase []byte) (*SecretStore, error) {
+	var header secretFile
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid secret file: %w", err)
+	}
+	if header.Version != secretFileVersion || header.KDF != secretKDF {
+		return nil, fmt.Errorf("unsupported secret file version %d (%s)", header.Version, header.KDF)
+	}
+	key, err := header.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	res := &SecretStore{Values: Values{}, header: header, key: key}
+	if len(header.Data) == 0 {
+		return res, nil
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, header.Nonce, header.Data, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt secrets: incorrect passphrase or corrupt file")
+	}
+	if err := json.Unmarshal(plaintext, &res.Values); err != nil {
+		return nil, fmt.Errorf("invalid secret file: %w", err)
+	}
+	return res, nil
+}
+
+// Set sets a secret value
+func (s *SecretStore) Set(key, value string) {
+	s.Values[key] = value
+}
+
+// Unset removes a secret value.  The result is false if the value does not exist.
+func (s *SecretStore) Unset(key string) bool {
+	if !s.Has(key) {
+		return false
+	}
+	delete(s.Values, key)
+	return true
+}
+
+// Bytes encrypts the values, producing the contents of the secret file.  A new
+// nonce is used each time.
+func (s *SecretStore) Bytes() ([]byte, error) {
+	plaintext, err := json.Marshal(s.Values)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := s.header
+	header.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(header.Nonce); err != nil {
+		return nil, err
+	}
+	header.Data = aead.Seal(nil, header.Nonce, plaintext, nil)
+	return json.MarshalIndent(header, "", "  ")
+}
+
+func (h secretFile) deriveKey(passphrase []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, string(passphrase), h.Salt, h.Iterations, 32)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SecretFile gets the path to the secret file.  The result is false if no secret
+// file was set or the variables in its path could not be resolved.
+func (c *Config) SecretFile(ctx context.Context) (string, bool) {
+	if c.secretFile == "" {
+		return "", false
+	}
+	return expandPath(c.upgradeContext(ctx), c.secretFile)
+}
+
+// Secrets unlocks the secret file, which requires the passphrase.  If the file does
+// not exist, an empty store is returned, and the passphrase which is prompted is
+// used when the store is saved by SetSecret.
+func (c *Config) Secrets(ctx context.Context) (*SecretStore, error) {
+	ctx = c.upgradeContext(ctx)
+	path, ok := c.SecretFile(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no secret file")
+	}
+
+	data, err := fs.ReadFile(layerFS(ctx), path)
+	if errors.Is(err, fs.ErrNotExist) {
+		passphrase, err := c.secretPassphrase(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		return NewSecretStore(passphrase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := c.secretPassphrase(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ParseSecretStore(data, passphrase)
+	if err != nil {
+		c.mu.Lock()
+		c.passphrase = nil
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return res, nil
+}
+
+// SetSecret sets a value in the secret file, creating the file if it does not
+// exist.  The store is invalidated so that it is loaded again.
+func (c *Config) SetSecret(ctx context.Context, key, value string) error {
+	return c.updateSecrets(ctx, func(s *SecretStore) error {
+		s.Set(key, value)
+		return nil
+	})
+}
+
+// UnsetSecret removes a value from the secret file.  An error is returned if the
+// file does not contain the value.
+func (c *Config) UnsetSecret(ctx context.Context, key string) error {
+	return c.updateSecrets(ctx, func(s *SecretStore) error {
+		if !s.Unset(key) {
+			return fmt.Errorf("no secret value for %s", key)
+		}
+		return nil
+	})
+}
+
+// IsSecret determines whether the value in the store was provided by the secret file
+func (c *Config) IsSecret(name any) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.secretKeys[nameToString(name)]
+}
+
+func (c *Config) updateSecrets(ctx context.Context, fn func(*SecretStore) error) error {
+	ctx = c.upgradeContext(ctx)
+	s, err := c.Secrets(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	data, err := s.Bytes()
+	if err != nil {
+		return err
+	}
+	path, _ := c.SecretFile(ctx)
+	if err := writeFile(layerFS(ctx), path, data, 0600); err != nil {
+		return err
+	}
+	c.store.Invalidate()
+	return nil
+}
+
+// mergeSecrets merges the values from the secret file into the user layer of the store.
+// When there is no key file, the passphrase would be prompted, so the secrets are
+// unlocked only when a value which isn't in the store is read.
+func (c *Config) mergeSecrets(ctx context.Context, store Store) (Store, error) {
+	c.setSecretKeys(nil)
+
+	path, ok := c.SecretFile(ctx)
+	if !ok || store == nil {
+		return store, nil
+	}
+	if _, err := statFile(layerFS(ctx), path); errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if c.secretKeyFile == "" {
+		return c.lockedSecrets(ctx, store), nil
+	}
+
+	secrets, err := c.Secrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := maps.Clone(secrets.Values)
+	override := false
+	if f, ok := store.(LayerFinder); ok {
+		override = true
+		maps.DeleteFunc(values, func(k, _ string) bool {
+			l, ok := f.Layer(k)
+			return ok && l > LayerUser
+		})
+	}
+	c.setSecretKeys(values)
+
+	return mergeValues(store, values, LayerUser, override), nil
+}
+
+// lockedSecretStore provides the values of a store, falling back to the values of the
+// secret file, which is unlocked the first time that a value not in the store is read.
+// Has doesn't unlock the secrets, so it only reports secret values once they are
+// unlocked.  When the secrets can't be unlocked, such as when there is no terminal
+// to prompt for the passphrase, a warning is printed and no secret values are provided.
+type lockedSecretStore struct {
+	wrapperStore
+	base Store
+
+	mu       sync.Mutex
+	unlocked bool
+	secrets  Values
+}
+
+func (c *Config) lockedSecrets(ctx context.Context, store Store) Store {
+	res := &lockedSecretStore{base: store}
+	unlock := func() Values {
+		res.mu.Lock()
+		defer res.mu.Unlock()
+		if res.unlocked {
+			return res.secrets
+		}
+		res.unlocked = true
+
+		secrets, err := c.Secrets(ctx)
+		if err != nil {
+			warnSecretsLocked(ctx, err)
+			return nil
+		}
+		res.secrets = maps.Clone(secrets.Values)
+		maps.DeleteFunc(res.secrets, func(k, _ string) bool {
+			return store.Has(k)
+		})
+		c.setSecretKeys(res.secrets)
+		return res.secrets
+	}
+
+	res.wrapperStore = wrapperStore{
+		Lookup: cli.LookupFunc(func(name string) (any, bool) {
+			if v, ok := store.Interface(name); ok {
+				return v, true
+			}
+			v, ok := unlock()[name]
+			return v, ok
+		}),
+		has: func(name string) bool {
+			return store.Has(name) || res.unlockedSecrets().Has(name)
+		},
+		keys: func() iter.Seq[string] {
+			return Keys(store)
+		},
+	}
+	return res
+}
+
+// unlockedSecrets gets the secret values if they were already unlocked
+func (s *lockedSecretStore) unlockedSecrets() Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.secrets
+}
+
+func (s *lockedSecretStore) Layer(name any) (Layer, bool) {
+	if f, ok := s.base.(LayerFinder); ok && s.base.Has(name) {
+		return f.Layer(name)
+	}
+	if s.unlockedSecrets().Has(name) {
+		return LayerUser, true
+	}
+	return 0, false
+}
+
+func (s *lockedSecretStore) Position(name any) (Position, bool) {
+	return findPosition(s.base, nameToString(name))
+}
+
+// warnSecretsLocked prints a warning that the secrets couldn't be unlocked
+func warnSecretsLocked(ctx context.Context, err error) {
+	if c, ok := cli.TryFromContext(ctx); ok && c != nil {
+		fmt.Fprintf(c.Stderr, "warning: secret values are unavailable: %v\n", err)
+	}
+}
+
+func (c *Config) setSecretKeys(values Values) {
+	var keys map[string]bool
+	if len(values) > 0 {
+		keys = map[string]bool{}
+		for k := range values {
+			keys[k] = true
+		}
+	}
+	c.mu.Lock()
+	c.secretKeys = keys
+	c.mu.Unlock()
+}
+
+// secretPassphrase gets the passphrase from the key file or by prompting for it.
+// The passphrase is kept so that it is only prompted once.  When create is set,
+// the passphrase must be entered twice.
+func (c *Config) secretPassphrase(ctx context.Context, create bool) ([]byte, error) {
+	c.mu.Lock()
+	passphrase := c.passphrase
+	c.mu.Unlock()
+	if passphrase != nil {
+		return passphrase, nil
+	}
+
+	if c.secretKeyFile != "" {
+		path, _ := expandPath(ctx, c.secretKeyFile)
+		data, err := fs.ReadFile(layerFS(ctx), path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret key file: %w", err)
+		}
+		passphrase = []byte(strings.TrimRight(string(data), " \t\r\n"))
+
+	} else {
+		var err error
+		passphrase, err = promptPassphrase(ctx, create)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase must not be empty")
+	}
+
+	c.mu.Lock()
+	c.passphrase = passphrase
+	c.mu.Unlock()
+	return passphrase, nil
+}
+
+func promptPassphrase(ctx context.Context, create bool) ([]byte, error) {
+	if _, ok := ctx.Value(detachedKey).(*detached); ok {
+		return nil, errSecretsLocked
+	}
+	c, ok := cli.TryFromContext(ctx)
+	if !ok || c == nil {
+		return nil, errSecretsLocked
+	}
+
+	prompt := "Passphrase for secrets: "
+	if create {
+		prompt = "New passphrase for secrets: "
+	}
+	res, err := c.ReadPasswordString(prompt)
+	fmt.Fprintln(c.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSecretsLocked, err)
+	}
+	if create {
+		confirm, err := c.ReadPasswordString("Confirm passphrase: ")
+		fmt.Fprintln(c.Stderr)
+		if err != nil {
+			return nil, err
+		}
+		if confirm != res {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+	return []byte(res), nil
+}
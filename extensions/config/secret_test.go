@@ -0,0 +1,208 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretStore", func() {
+
+	It("decrypts the values using the passphrase", func() {
+		s, _ := config.NewSecretStore([]byte("hunter2"))
+		s.Set("github.token", "ghp_123")
+		data, err := s.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("ghp_123"))
+		Expect(string(data)).NotTo(ContainSubstring("github.token"))
+
+		actual, err := config.ParseSecretStore(data, []byte("hunter2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.String("github.token")).To(Equal("ghp_123"))
+	})
+
+	It("returns an error when the passphrase is incorrect", func() {
+		s, _ := config.NewSecretStore([]byte("hunter2"))
+		data, _ := s.Bytes()
+
+		_, err := config.ParseSecretStore(data, []byte("wrong"))
+		Expect(err).To(MatchError("failed to decrypt secrets: incorrect passphrase or corrupt file"))
+	})
+})
+
+var _ = Describe("WithSecretFile", func() {
+
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		stderr *bytes.Buffer
+		flags  []*cli.Flag
+		cfg    *config.Config
+
+		run = func(arguments string, opts ...config.Option) error {
+			cfg = config.New(append([]config.Option{
+				config.WithLocation(config.Locations(
+					config.LayerLocation(config.LayerUser, filepath.Join(dir, "user.json")),
+					config.LayerLocation(config.LayerWorkspace, filepath.Join(dir, "workspace.json")),
+				)),
+				config.WithSecretFile(filepath.Join(dir, "secrets.json")),
+			}, opts...)...)
+			app := &cli.App{
+				Name:   "app",
+				Stdin:  strings.NewReader(""),
+				Stdout: stdout,
+				Stderr: stderr,
+				Flags:  flags,
+				Uses:   cli.Pipeline(cfg, config.Commands()),
+				Action: func() {},
+			}
+			args, _ := cli.Split(arguments)
+			return app.RunContext(context.Background(), args)
+		}
+		withKeyFile = func() config.Option {
+			return config.WithSecretKeyFile(filepath.Join(dir, "key"))
+		}
+		writeFile = func(name, data string) {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(data), 0644)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+		flags = nil
+		writeFile("user.json", `{"name": "user", "token": "plaintext"}`)
+		writeFile("workspace.json", `{"server": {"port": 8080}}`)
+		writeFile("key", "correct horse battery staple\n")
+	})
+
+	It("sets and gets secret values", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app config secret get github.token", withKeyFile())).To(Succeed())
+		Expect(stdout.String()).To(Equal("ghp_123\n"))
+
+		data, _ := os.ReadFile(filepath.Join(dir, "secrets.json"))
+		Expect(string(data)).NotTo(ContainSubstring("ghp_123"))
+	})
+
+	It("provides secret values from the store in the user layer", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app config secret set server.port 9000", withKeyFile())).To(Succeed())
+		Expect(run("app config secret set token secret", withKeyFile())).To(Succeed())
+		Expect(run("app", withKeyFile())).To(Succeed())
+
+		Expect(cfg.String("github.token")).To(Equal("ghp_123"))
+		Expect(cfg.String("token")).To(Equal("secret"))
+		Expect(cfg.Int("server.port")).To(Equal(8080))
+		Expect(cfg.IsSecret("github.token")).To(BeTrue())
+		Expect(cfg.IsSecret("server.port")).To(BeFalse())
+
+		layer, _ := cfg.Store().(config.LayerFinder).Layer("github.token")
+		Expect(layer).To(Equal(config.LayerUser))
+	})
+
+	It("does not interpolate secret values", func() {
+		Expect(run("app config secret set password 'pa$$word'", withKeyFile())).To(Succeed())
+		Expect(run("app", withKeyFile())).To(Succeed())
+		Expect(cfg.String("password")).To(Equal("pa$$word"))
+	})
+
+	It("redacts secret values in the list", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app config list", withKeyFile())).To(Succeed())
+		Expect(stdout.String()).To(ContainSubstring("github.token=" + cli.Redacted + "\n"))
+		Expect(stdout.String()).To(ContainSubstring("name=user\n"))
+	})
+
+	It("removes secret values", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app config secret rm github.token", withKeyFile())).To(Succeed())
+		Expect(run("app config secret get github.token", withKeyFile())).To(MatchError("no secret value for github.token"))
+		Expect(run("app config secret rm github.token", withKeyFile())).To(MatchError("no secret value for github.token"))
+	})
+
+	It("returns an error when the key file is incorrect", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		writeFile("key", "wrong")
+
+		err := run("app", withKeyFile())
+		Expect(err).To(MatchError(ContainSubstring("incorrect passphrase or corrupt file")))
+	})
+
+	It("does not provide secret values when the passphrase can't be prompted", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+
+		Expect(run("app")).To(Succeed())
+		Expect(cfg.String("name")).To(Equal("user"))
+		Expect(cfg.String("github.token")).To(BeEmpty())
+		Expect(cfg.IsSecret("github.token")).To(BeFalse())
+		Expect(stderr.String()).To(ContainSubstring("warning: secret values are unavailable: secrets are locked"))
+	})
+
+	It("does not prompt for the passphrase to check whether a value exists", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		flags = []*cli.Flag{
+			{Name: "color", Uses: config.ConfigKey("ui.color")},
+		}
+
+		Expect(run("app")).To(Succeed())
+		Expect(cfg.Has("ui.color")).To(BeFalse())
+		Expect(stderr.String()).NotTo(ContainSubstring("Passphrase"))
+	})
+
+	It("unlocks the secrets for secret flags bound to config", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		flags = []*cli.Flag{
+			{Name: "token", Value: cli.Secret(), Uses: config.ConfigKey("github.token")},
+		}
+
+		Expect(run("app")).To(Succeed())
+		Expect(stderr.String()).To(ContainSubstring("Passphrase for secrets: "))
+	})
+
+	It("redacts secret values in get", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app config get github.token", withKeyFile())).To(Succeed())
+		Expect(stdout.String()).To(Equal(cli.Redacted + "\n"))
+	})
+
+	It("writes the secret file so that only the user can read it", func() {
+		if runtime.GOOS == "windows" {
+			Skip("file permissions are not supported")
+		}
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+
+		info, err := os.Stat(filepath.Join(dir, "secrets.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("does not report secrets after the secret file is removed", func() {
+		Expect(run("app config secret set github.token ghp_123", withKeyFile())).To(Succeed())
+		Expect(run("app", withKeyFile())).To(Succeed())
+		Expect(cfg.IsSecret("github.token")).To(BeTrue())
+
+		Expect(os.Remove(filepath.Join(dir, "secrets.json"))).To(Succeed())
+		_, err := cfg.Load(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.IsSecret("github.token")).To(BeFalse())
+	})
+
+	It("does not require a passphrase when there is no secret file", func() {
+		Expect(run("app")).To(Succeed())
+		Expect(cfg.String("name")).To(Equal("user"))
+	})
+})
@@ -7,7 +7,10 @@ package config
 import (
 	"context"
 	"fmt"
+	"iter"
+	"maps"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/Carbonfrost/joe-cli"
@@ -21,6 +24,12 @@ type Store interface {
 	Has(name any) bool
 }
 
+// KeyLister is implemented by a store that can enumerate the names of its values.
+type KeyLister interface {
+	// Keys enumerates the qualified names of the values in the store
+	Keys() iter.Seq[string]
+}
+
 // ReloadableStore provides a store which can be reloaded.
 type ReloadableStore interface {
 	Store
@@ -31,6 +40,39 @@ type ReloadableStore interface {
 	Reload(context.Context) error
 }
 
+// LayerFinder is implemented by a store that can determine the layer which
+// provides each of its values.
+type LayerFinder interface {
+	// Layer gets the layer which provides the value with the given name
+	Layer(name any) (Layer, bool)
+}
+
+// PositionFinder is implemented by a store that can determine where each of
+// its values is defined.
+type PositionFinder interface {
+	// Position gets the position where the value with the given name is defined
+	Position(name any) (Position, bool)
+}
+
+// Position identifies where a value is defined within a file
+type Position struct {
+	// File is the path to the file
+	File string
+
+	// Line is the line number starting at 1, or 0 if it is not known
+	Line int
+}
+
+// LayeredValues provides a config Store which combines the values from
+// several layers.  When more than one layer provides a value, the value
+// from the highest layer is used, so values in the workspace layer take precedence
+// over the user layer, which takes precedence over the system layer.
+type LayeredValues struct {
+	Values
+	layers    map[string]Layer
+	positions map[string]Position
+}
+
 //counterfeiter:generate . Loader
 
 // Loader loads the configuration system
@@ -45,6 +87,15 @@ func NewStore(base cli.Lookup, has func(string) bool) Store {
 	}
 }
 
+// Keys enumerates the names of the values in the store.  If the store does not
+// implement KeyLister, the sequence is empty.
+func Keys(s Store) iter.Seq[string] {
+	if k, ok := s.(KeyLister); ok {
+		return k.Keys()
+	}
+	return func(func(string) bool) {}
+}
+
 // FromValues creates a store from the specified values
 func FromValues(values ...Value) (Store, error) {
 	lv := cli.LookupValues{}
@@ -59,15 +110,59 @@ func FromValues(values ...Value) (Store, error) {
 			lv[v.Name] = v.Value
 		}
 	}
-	return NewStore(lv, func(key string) bool {
-		_, ok := lv[key]
-		return ok
-	}), nil
+	return wrapperStore{
+		Lookup: lv,
+		has: func(key string) bool {
+			_, ok := lv[key]
+			return ok
+		},
+		keys: func() iter.Seq[string] {
+			return slices.Values(slices.Sorted(maps.Keys(lv)))
+		},
+	}, nil
+}
+
+// NewLayeredValues creates a store from the values in each layer
+func NewLayeredValues(layers map[Layer]Values) *LayeredValues {
+	res := &LayeredValues{
+		Values:    Values{},
+		layers:    map[string]Layer{},
+		positions: map[string]Position{},
+	}
+	for _, l := range slices.Sorted(maps.Keys(layers)) {
+		for k, v := range layers[l] {
+			res.Values[k] = v
+			res.layers[k] = l
+		}
+	}
+	return res
+}
+
+// Layer gets the layer which provides the value with the given name
+func (l *LayeredValues) Layer(name any) (Layer, bool) {
+	res, ok := l.layers[nameToString(name)]
+	return res, ok
+}
+
+// Position gets the position of the file which provides the value with the given name.
+// Positions are available when the values were loaded from files (see LayerLocation).
+func (l *LayeredValues) Position(name any) (Position, bool) {
+	res, ok := l.positions[nameToString(name)]
+	return res, ok
+}
+
+// String produces the file and line, such as config.toml:12
+func (p Position) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return p.File
 }
 
 type wrapperStore struct {
 	cli.Lookup
-	has func(string) bool
+	has  func(string) bool
+	keys func() iter.Seq[string]
 }
 
 var empty = wrapperStore{
@@ -81,6 +176,13 @@ func (w wrapperStore) Has(v any) bool {
 	return w.has(nameToString(v))
 }
 
+func (w wrapperStore) Keys() iter.Seq[string] {
+	if w.keys == nil {
+		return func(func(string) bool) {}
+	}
+	return w.keys()
+}
+
 // WithLoader specifies a loader that can be used to generate the store.
 func WithLoader(l Loader) Option {
 	return optionFunc(func(c *Config) {
@@ -92,6 +194,7 @@ func WithLoader(l Loader) Option {
 
 type storeCache struct {
 	fn        func(context.Context) (Store, error)
+	post      func(context.Context, Store) (Store, error)
 	mu        sync.RWMutex
 	store     Store
 	once      sync.Once
@@ -123,7 +226,8 @@ func (c *storeCache) ensureStore(ctx context.Context) Store {
 			c.store = empty
 			return
 		}
-		store, err := c.fn(ctx)
+		c.lastError = nil
+		store, err := c.load(ctx)
 		if err != nil {
 			c.lastError = err
 			c.store = nil
@@ -140,6 +244,36 @@ func (c *storeCache) ensureStore(ctx context.Context) Store {
 	return c.store
 }
 
+// refresh loads the store again and replaces the cached store.  Unlike
+// Invalidate, the previous store is kept when loading fails.
+func (c *storeCache) refresh(ctx context.Context) (old, store Store, err error) {
+	if c.fn == nil {
+		return nil, nil, nil
+	}
+	store, err = c.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if store == nil {
+		store = empty
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old = c.store
+	c.store = store
+	c.lastError = nil
+	return old, store, nil
+}
+
+func (c *storeCache) load(ctx context.Context) (Store, error) {
+	store, err := c.fn(ctx)
+	if err == nil && c.post != nil {
+		store, err = c.post(ctx, store)
+	}
+	return store, err
+}
+
 func nameToString(name any) string {
 	switch v := name.(type) {
 	case rune:
@@ -0,0 +1,224 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+)
+
+// WatchOptions provides options for watching the configuration files
+type WatchOptions struct {
+	// Interval is how often the files are checked for changes.  The
+	// default is one second.
+	Interval time.Duration
+
+	// Debounce is how long the files must remain unchanged after a change is
+	// detected before the store is reloaded, which prevents reloading
+	// several times while an editor writes a file.  Because the files are
+	// only checked at each Interval, the store is reloaded by the first check
+	// which finds the files unchanged at least Debounce after the last change,
+	// so the delay is never shorter than Interval.  The default is 100ms.
+	Debounce time.Duration
+
+	// ErrorHandler is called when the store can't be reloaded.  The previous store
+	// remains in use.  By default, a warning is printed to stderr.
+	ErrorHandler func(error)
+}
+
+// detached provides the values from the cli.Context which are used by the
+// watcher because the cli.Context can't be used from another goroutine
+type detached struct {
+	appName string
+	fs      cli.FS
+	stderr  io.Writer
+}
+
+const detachedKey key = "detached"
+
+// watcher is a watch started by WatchChanges
+type watcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type watcherKey struct{}
+
+// fileStamp identifies the version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// OnChange adds a function which is called when the store is reloaded because
+// the configuration files changed (see Watch).  The function receives the previous
+// store and the store which replaced it.
+func (c *Config) OnChange(fn func(old, new Store)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changeHandlers = append(c.changeHandlers, fn)
+}
+
+// Watch watches the configuration files until the context is done.  The files are
+// those from Paths (see WithLocation).  Because the files are polled, this works
+// with any file system, including the file system of the app.  When a file is
+// created, changed, or removed, the store is reloaded and the functions registered
+// with OnChange are called.  To watch the files in the background while a command
+// runs, use WatchChanges.
+func (c *Config) Watch(ctx context.Context, opts WatchOptions) error {
+	watchCtx, cancel := context.WithCancel(c.detachContext(ctx))
+	defer cancel()
+	defer context.AfterFunc(ctx, cancel)()
+
+	return c.watch(watchCtx, opts, c.stampFiles(watchCtx))
+}
+
+// watch polls the files for changes starting from the given versions of the files
+func (c *Config) watch(ctx context.Context, opts WatchOptions, last map[string]fileStamp) error {
+	interval := cmp.Or(opts.Interval, time.Second)
+	debounce := cmp.Or(opts.Debounce, 100*time.Millisecond)
+	handleError := opts.ErrorHandler
+	if handleError == nil {
+		handleError = func(err error) {
+			fmt.Fprintf(ctx.Value(detachedKey).(*detached).stderr, "warning: failed to reload config: %v\n", err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// The time of the last change which hasn't been reloaded yet, if any
+	var changed time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+
+		case now := <-ticker.C:
+			current := c.stampFiles(ctx)
+			if !maps.Equal(last, current) {
+				last = current
+				changed = now
+				continue
+			}
+			if changed.IsZero() || now.Sub(changed) < debounce {
+				continue
+			}
+
+			changed = time.Time{}
+			if err := c.refresh(ctx); err != nil {
+				handleError(err)
+			}
+		}
+	}
+}
+
+// WatchChanges provides an action which watches the configuration files in the
+// background while the command runs.  This is intended for long-running commands
+// such as daemons, which can use OnChange to be notified when the configuration
+// changes.  The watcher is stopped after the command runs.
+func WatchChanges(opts WatchOptions) cli.Action {
+	// Each invocation stores its watcher in the context using this key
+	key := new(watcherKey)
+	return cli.Pipeline(
+		cli.Before(cli.ActionOf(func(c *cli.Context) error {
+			cfg := FromContext(c)
+			watchCtx, cancel := context.WithCancel(cfg.detachContext(c))
+			w := &watcher{cancel: cancel, done: make(chan struct{})}
+
+			// Files are checked before returning so that changes made by the
+			// command are detected
+			last := cfg.stampFiles(watchCtx)
+			go func() {
+				defer close(w.done)
+				_ = cfg.watch(watchCtx, opts, last)
+			}()
+			return c.SetContextValue(key, w)
+		})),
+		cli.After(cli.ActionOf(func(c context.Context) {
+			if w, ok := c.Value(key).(*watcher); ok {
+				w.cancel()
+				<-w.done
+			}
+		})),
+	)
+}
+
+// detachContext copies the values used by the config from the context so that they
+// can be used by another goroutine.  The result is not derived from the context.
+func (c *Config) detachContext(ctx context.Context) context.Context {
+	d := &detached{
+		appName: appName(ctx),
+		fs:      layerFS(ctx),
+		stderr:  os.Stderr,
+	}
+	if cc, ok := cli.TryFromContext(ctx); ok && cc != nil {
+		d.stderr = cc.Stderr
+	}
+	return c.upgradeContext(context.WithValue(context.Background(), detachedKey, d))
+}
+
+// refresh reloads the store and notifies the change handlers
+func (c *Config) refresh(ctx context.Context) error {
+	old, store, err := c.store.refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	handlers := slices.Clone(c.changeHandlers)
+	c.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(old, store)
+	}
+	return nil
+}
+
+// watchedFiles gets the paths to the configuration files
+func (c *Config) watchedFiles(ctx context.Context) []string {
+	var res []string
+	if c.location != nil {
+		res, _ = c.Paths(ctx)
+	}
+	return res
+}
+
+// stampFiles gets the version of each of the configuration files.  Files
+// which don't exist are omitted, so that creating or removing a file is a change.
+func (c *Config) stampFiles(ctx context.Context) map[string]fileStamp {
+	fsys := layerFS(ctx)
+	res := map[string]fileStamp{}
+	for _, path := range c.watchedFiles(ctx) {
+		if info, err := statFile(fsys, path); err == nil {
+			res[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return res
+}
+
+// statFile gets the file info by opening the file, which is supported
+// by every file system
+func statFile(fsys fs.FS, path string) (fs.FileInfo, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
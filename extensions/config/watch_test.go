@@ -0,0 +1,123 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"io/fs"
+	"sync"
+	"testing/fstest"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+
+	var (
+		files *syncFS
+		run   = func(opts config.WatchOptions, action func(*cli.Context, *config.Config)) error {
+			app := &cli.App{
+				Name: "app",
+				FS:   files,
+				Uses: cli.Pipeline(
+					config.New(config.WithLocation(config.Locations(
+						config.LayerLocation(config.LayerUser, "app.json"),
+					))),
+					config.WatchChanges(opts),
+				),
+				Action: func(c *cli.Context) {
+					action(c, config.FromContext(c))
+				},
+			}
+			return app.RunContext(context.Background(), []string{"app"})
+		}
+		opts = config.WatchOptions{
+			Interval: 5 * time.Millisecond,
+			Debounce: 50 * time.Millisecond,
+		}
+	)
+
+	BeforeEach(func() {
+		files = &syncFS{files: fstest.MapFS{}}
+		files.write("app.json", `{"name": "before"}`)
+	})
+
+	It("reloads the store and notifies the change", func() {
+		Expect(run(opts, func(c *cli.Context, cfg *config.Config) {
+			changes := make(chan [2]string, 10)
+			cfg.OnChange(func(old, new config.Store) {
+				changes <- [2]string{old.String("name"), new.String("name")}
+			})
+
+			files.write("app.json", `{"name": "after"}`)
+			Eventually(changes).Should(Receive(Equal([2]string{"before", "after"})))
+			Expect(cfg.String("name")).To(Equal("after"))
+		})).To(Succeed())
+	})
+
+	It("stops watching after the command runs", func() {
+		changes := make(chan string, 10)
+		Expect(run(opts, func(c *cli.Context, cfg *config.Config) {
+			cfg.OnChange(func(_, new config.Store) {
+				changes <- new.String("name")
+			})
+		})).To(Succeed())
+
+		files.write("app.json", `{"name": "after"}`)
+		Consistently(changes, 100*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("debounces changes", func() {
+		Expect(run(opts, func(c *cli.Context, cfg *config.Config) {
+			changes := make(chan string, 10)
+			cfg.OnChange(func(_, new config.Store) {
+				changes <- new.String("name")
+			})
+
+			for _, name := range []string{"a", "bb", "ccc"} {
+				files.write("app.json", `{"name": "`+name+`"}`)
+				time.Sleep(10 * time.Millisecond)
+			}
+			Eventually(changes).Should(Receive(Equal("ccc")))
+			Consistently(changes, 100*time.Millisecond).ShouldNot(Receive())
+		})).To(Succeed())
+	})
+
+	It("keeps the previous store when it can't be reloaded", func() {
+		errs := make(chan error, 10)
+		opts := opts
+		opts.ErrorHandler = func(err error) {
+			errs <- err
+		}
+		Expect(run(opts, func(c *cli.Context, cfg *config.Config) {
+			files.write("app.json", "{unterminated")
+			Eventually(errs).Should(Receive())
+			Expect(cfg.String("name")).To(Equal("before"))
+		})).To(Succeed())
+	})
+})
+
+// syncFS provides an in-memory file system which can be modified while
+// it is being watched
+type syncFS struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+func (s *syncFS) Open(name string) (fs.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.files.Open(name)
+}
+
+func (s *syncFS) write(name, data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = &fstest.MapFile{Data: []byte(data), ModTime: time.Now()}
+}
@@ -14,6 +14,7 @@ import (
 	"iter"
 	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"slices"
@@ -29,7 +30,10 @@ import (
 //     the workspace is found by searching up the directory hierarchy until it
 //     finds a directory which contains a configuration sentinel, typically a
 //     directory which the same name as the app with a leading period. For example,
-//     this is what Git does when it finds the .git directory.
+//     this is what Git does when it finds the .git directory.  Workspaces can be
+//     nested, and the workspaces which contain the workspace are also found (see
+//     Roots).  The workspace directory can also be pinned (see WithWorkspaceDir,
+//     WithWorkspaceDirEnv, and SetWorkspaceDir).
 //  2. The workspace can load files. These files could be application files or
 //     configuration files.
 type Workspace struct {
@@ -42,6 +46,17 @@ type Workspace struct {
 	files     WorkspaceFileSet[any]
 	finder    WorkspaceFinder
 	env       EnvProvider
+
+	dotEnvFiles []string
+	dotEnv      EnvMap
+	secretEnv   map[string]bool
+
+	pinned     string
+	pinnedBy   string
+	dirEnv     string
+	dirFromEnv bool
+	roots      []string
+	candidates []WorkspaceCandidate
 }
 
 // WorkspaceFileSet enumerates and loads files within a workspace. It holds the
@@ -52,10 +67,20 @@ type Workspace struct {
 // A file set is obtained from a workspace via [Workspace.FileSet], which
 // delegates the workspace's own [Workspace.Files] and [Workspace.LoadFiles]
 // methods to it.
+//
+// The files can be filtered using patterns and ignore files (see [WithInclude],
+// [WithExclude], and [WithIgnoreFiles]), and the results of the loader can be
+// cached across invocations of the app (see [WithFileIndex]).
 type WorkspaceFileSet[T any] struct {
 	f           fs.FS
 	walkDirFunc fs.WalkDirFunc
 	loader      func(fs.FS, string, fs.DirEntry) (T, error)
+	filter      fileFilter
+	index       string
+	dir         string
+	cacheDir    string
+	version     string
+	indexFS     cli.FS
 }
 
 // WorkspaceOption provides an option for setting up the Workspace. This is also
@@ -145,11 +170,17 @@ func ContextValue(v contextValue) cli.Action {
 }
 
 // SetupWorkspace provides the action that sets up the workspace, which locks in
-// the workspace directories.
+// the workspace directories and loads the dotenv files (see WithDotEnv).
 func SetupWorkspace() cli.Action {
 	return cli.Before(cli.ActionOf(func(c context.Context) error {
 		if ws, err := tryWorkspaceFromContext(c); err == nil {
 			ws.completeSetup(c)
+			if err := ws.loadDotEnv(); err != nil {
+				return err
+			}
+			if len(ws.dotEnvFiles) > 0 {
+				return cli.Do(c, cli.SetLookupEnv(ws.lookupEnv))
+			}
 		}
 		return nil
 	}))
@@ -209,7 +240,8 @@ func SetConfigDir(diropt ...string) cli.Action {
 
 // PrintEnv prints out the environment, optionally specifying the vars to
 // print out. When used in the Uses pipeline, it also provides useful initialization
-// to the command it is used on.
+// to the command it is used on.  The values of environment variables which provide
+// secret values are redacted (see Workspace.FprintEnv).
 func PrintEnv(vars ...string) cli.Action {
 	var varAction bind.Binder[[]string]
 	if len(vars) == 0 {
@@ -227,9 +259,11 @@ func PrintEnv(vars ...string) cli.Action {
 			Options: cli.Exits,
 		},
 		bind.Call3(
-			(*Workspace).fprintEnv,
+			func(w *Workspace, c *cli.Context, vars []string) error {
+				return w.FprintEnv(c.Stdout, vars...)
+			},
 			bind.FromContext(WorkspaceFromContext),
-			bind.Stdout(),
+			bind.Context(),
 			varAction,
 		),
 	)
@@ -251,17 +285,26 @@ func (w *Workspace) Dir() string {
 }
 
 func (w *Workspace) completeSetup(c context.Context) {
+	if w.secretEnv == nil {
+		w.secretEnv = secretEnvVars(c)
+	}
 	if w.dir == "" {
 		cwd, _ := os.Getwd()
-
-		finder := cmp.Or(w.finder, WorkspaceFinder(DefaultWorkspaceFinder))
-
-		cwd, _ = finder.FindWorkspacePath(c, actualFS(c), cwd)
+		cwd = w.findRoots(c, cwd)
 		w.dir = cwd
 
 		// TODO Seems like this should be a sub-FS on the actualFS rather than
 		// always in the file system
-		w.files.f = cmp.Or(w.files.f, os.DirFS(cwd))
+		if w.files.f == nil {
+			w.files.f = os.DirFS(cwd)
+
+			// The index is only used when the workspace directory is the root
+			// of the file system so that it identifies the files
+			w.files.dir = cwd
+		}
+		w.files.cacheDir, _ = lookupSpecialVar(c, "cli:cache")
+		w.files.version = appVersion(c)
+		w.files.indexFS = layerFS(c)
 		w.configDir = cmp.Or(w.configDir, filepath.Join(cwd, "."+appName(c)))
 	}
 }
@@ -280,7 +323,10 @@ func (w *Workspace) FS() fs.FS {
 // Env obtains the workspace environment
 func (w *Workspace) Env() iter.Seq2[string, string] {
 	m := map[string]string{}
-	maps.Insert(m, w.env.Environ())
+	if w.env != nil {
+		maps.Insert(m, w.env.Environ())
+	}
+	maps.Copy(m, w.dotEnv)
 
 	return func(yield func(string, string) bool) {
 		for _, key := range slices.Sorted(maps.Keys(m)) {
@@ -291,8 +337,36 @@ func (w *Workspace) Env() iter.Seq2[string, string] {
 	}
 }
 
-func (w *Workspace) fprintEnv(out io.Writer, vars []string) error {
-	return w.FprintEnv(out, vars...)
+// secretEnvVars gets the names of environment variables which provide
+// secret values (see cli.Secret) to flags and args of any command in the app
+func secretEnvVars(c context.Context) map[string]bool {
+	res := map[string]bool{}
+	ctx, ok := cli.TryFromContext(c)
+	if !ok || ctx == nil {
+		return res
+	}
+
+	add := func(value any, envVars []string) {
+		if _, ok := value.(*cli.SecretValue); ok {
+			for _, e := range envVars {
+				res[e] = true
+			}
+		}
+	}
+	var walk func(*cli.Command)
+	walk = func(cmd *cli.Command) {
+		for _, f := range cmd.Flags {
+			add(f.Value, f.EnvVars)
+		}
+		for _, a := range cmd.Args {
+			add(a.Value, a.EnvVars)
+		}
+		for _, sub := range cmd.Subcommands {
+			walk(sub)
+		}
+	}
+	walk(ctx.Root().Command())
+	return res
 }
 
 // Getenv looks up a name in the workspace Env
@@ -316,17 +390,26 @@ func (w *Workspace) PrintEnv(vars ...string) error {
 // FprintEnv prints the environment to the specified writer. If any variable is named
 // then just its value is printed. However, if no variables are
 // named then all are printed out using the syntax of shell variable
-// assignment for the operating system.
+// assignment for the operating system.  The values of environment variables which
+// provide a secret value (see cli.Secret) to a flag or arg of any command in the
+// app are redacted.
 func (w *Workspace) FprintEnv(out io.Writer, vars ...string) error {
+	redact := func(k, v string) string {
+		if w.secretEnv[k] {
+			return cli.Redacted
+		}
+		return v
+	}
+
 	if len(vars) == 0 {
 		for k, v := range w.Env() {
-			fmt.Fprintf(out, "%s %s=%s\n", exportSym, k, cli.Quote(v))
+			fmt.Fprintf(out, "%s %s=%s\n", exportSym, k, cli.Quote(redact(k, v)))
 		}
 		return nil
 	}
 
 	for _, v := range vars {
-		fmt.Fprintln(out, w.Getenv(v))
+		fmt.Fprintln(out, redact(v, w.Getenv(v)))
 	}
 	return nil
 }
@@ -357,6 +440,7 @@ func WithFinder(finder WorkspaceFinder) WorkspaceOption {
 func WithFS(f fs.FS) WorkspaceOption {
 	return workspaceOption(func(w *Workspace) error {
 		w.files.f = f
+		w.files.dir = ""
 		return nil
 	})
 }
@@ -407,6 +491,12 @@ func NewFileSet[T any](w *Workspace, loader func(root fs.FS, name string, d fs.D
 		f:           w.files.f,
 		walkDirFunc: w.files.walkDirFunc,
 		loader:      loader,
+		filter:      w.files.filter,
+		index:       w.files.index,
+		dir:         w.files.dir,
+		cacheDir:    w.files.cacheDir,
+		version:     w.files.version,
+		indexFS:     w.files.indexFS,
 	}
 }
 
@@ -446,20 +536,7 @@ func (s *WorkspaceFileSet[T]) Files(diropt ...string) iter.Seq2[string, fs.DirEn
 // are expected to change while the app is running. The type of the items
 // returned from this is determined by the loader.
 func (s *WorkspaceFileSet[T]) LoadFiles(diropt ...string) iter.Seq[T] {
-	return func(yield func(T) bool) {
-		_ = s.walkDir(func(path string, d fs.DirEntry, _ error) error {
-			loaded, err := s.loader(s.f, path, d)
-			if err != nil {
-				return err
-			}
-
-			if !yield(loaded) {
-				return fs.SkipAll
-			}
-
-			return nil
-		}, diropt...)
-	}
+	return s.load(nil, diropt...)
 }
 
 func (s *WorkspaceFileSet[T]) walkDir(fn fs.WalkDirFunc, diropt ...string) error {
@@ -480,6 +557,8 @@ func (s *WorkspaceFileSet[T]) walkDir(fn fs.WalkDirFunc, diropt ...string) error
 		}
 	}
 
+	prefix := slashPaths(diropt)
+	rules := map[string][]ignoreRule{}
 	return fs.WalkDir(rootFS, ".", func(name string, d fs.DirEntry, err error) error {
 		err = walkDirFunc(name, d, err)
 		if SkipFile == err {
@@ -494,6 +573,13 @@ func (s *WorkspaceFileSet[T]) walkDir(fn fs.WalkDirFunc, diropt ...string) error
 			return nil
 		}
 
+		if name != "." && s.filter.skip(s.f, path.Join(append(prefix, name)...), d.IsDir(), rules) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
 		if d.IsDir() {
 			return nil
 		}
@@ -504,16 +590,14 @@ func (s *WorkspaceFileSet[T]) walkDir(fn fs.WalkDirFunc, diropt ...string) error
 
 func (*Workspace) contextValueSigil() {}
 
-func (defaultWorkspaceFinder) FindWorkspacePath(c context.Context, f fs.FS, cwd string) (string, error) {
-	var sentinel = "." + appName(c)
-
+func (d defaultWorkspaceFinder) FindWorkspacePath(c context.Context, f fs.FS, cwd string) (string, error) {
+	sentinel := "." + appName(c)
 	for _, ws := range ancestorPaths(cwd) {
-		f, err := fs.Stat(f, filepath.Join(ws, sentinel))
-		if err == nil && f.IsDir() {
-			return ws, nil
+		if candidate := d.check(f, ws, sentinel); candidate.Accepted {
+			return candidate.Dir, nil
 		}
 	}
-	return "", errWorkspaceNotFound
+	return "", fmt.Errorf("%w: no %s directory in %s or its parent directories", errWorkspaceNotFound, sentinel, cwd)
 }
 
 func actualFS(ctx context.Context) fs.FS {
@@ -525,6 +609,9 @@ func actualFS(ctx context.Context) fs.FS {
 }
 
 func appName(ctx context.Context) string {
+	if d, ok := ctx.Value(detachedKey).(*detached); ok {
+		return d.appName
+	}
 	c, ok := cli.TryFromContext(ctx)
 	if !ok || c == nil {
 		return "config"
@@ -532,6 +619,14 @@ func appName(ctx context.Context) string {
 	return strings.ToLower(c.App().Name)
 }
 
+func appVersion(ctx context.Context) string {
+	c, ok := cli.TryFromContext(ctx)
+	if !ok || c == nil {
+		return ""
+	}
+	return c.App().Version
+}
+
 func ancestorPaths(c string) []string {
 	res := []string{}
 	for ; ; c = filepath.Dir(c) {
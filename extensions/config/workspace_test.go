@@ -79,6 +79,51 @@ var _ = Describe("PrintEnv", func() {
 		Entry("all variables", config.PrintEnv(), "app", "export HELLO=R\nexport XXX=S\n"),
 		Entry("specified variable", config.PrintEnv("XXX"), "app", "S\n"),
 	)
+
+	It("redacts variables which provide secrets", func() {
+		SkipOnWindows()
+		var captured bytes.Buffer
+
+		app := &cli.App{
+			Uses: cli.Pipeline(
+				config.NewWorkspace(
+					config.WithEnvProvider(config.EnvMap{"HELLO": "R", "TOKEN": "hunter2"}),
+				),
+				config.PrintEnv(),
+			),
+			Flags: []*cli.Flag{
+				{Name: "token", Value: cli.Secret(), EnvVars: []string{"TOKEN"}},
+			},
+			Stdout: &captured,
+		}
+		args, _ := cli.Split("app")
+		app.RunContext(context.Background(), args)
+		Expect(captured.String()).To(Equal("export HELLO=R\nexport TOKEN='[redacted]'\n"))
+	})
+
+	It("redacts variables which provide secrets to other commands", func() {
+		SkipOnWindows()
+		var captured bytes.Buffer
+
+		app := &cli.App{
+			Uses: config.NewWorkspace(
+				config.WithEnvProvider(config.EnvMap{"HELLO": "R", "TOKEN": "hunter2"}),
+			),
+			Commands: []*cli.Command{
+				{Name: "env", Uses: config.PrintEnv()},
+				{
+					Name: "login",
+					Flags: []*cli.Flag{
+						{Name: "token", Value: cli.Secret(), EnvVars: []string{"TOKEN"}},
+					},
+				},
+			},
+			Stdout: &captured,
+		}
+		args, _ := cli.Split("app env TOKEN")
+		_ = app.RunContext(context.Background(), args)
+		Expect(captured.String()).To(Equal("[redacted]\n"))
+	})
 })
 
 var _ = Describe("Workspace", func() {
@@ -0,0 +1,226 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	eexec "os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Carbonfrost/joe-cli"
+)
+
+// PluginCategory is the category used for commands that are provided by
+// external plugins
+const PluginCategory = "Plugins"
+
+// Plugins provides an action which discovers external sub-commands.  A plugin
+// is an executable named APP-COMMAND, where APP is the name of the app, located in
+// one of the specified directories or on PATH.  The directories are searched first,
+// in order, and the first executable found for a given command wins.  Plugins never
+// replace commands that are already defined.
+//
+// Discovered plugins are added as commands in the category PluginCategory so that
+// they are listed in help and considered by SuggestCommand.  A command not found
+// handler is also registered so that plugins installed after initialization are found.
+//
+// When a plugin is executed, the remaining arguments are passed to it, and each
+// persistent flag that was set on an ancestor command is exported as an environment
+// variable named APP_FLAG, in upper case with hyphens replaced by underscores.
+// For example, --log-level on the app named "git" is exported as GIT_LOG_LEVEL.
+//
+// Completion requests are forwarded to plugins using the same environment
+// variable contract used by dynamic shell completion (see cli.CompletionEnvVar), so
+// plugins which are themselves built with joe-cli complete their own arguments.
+func Plugins(dir ...string) cli.Action {
+	dirs := slices.Clone(dir)
+	return cli.Pipeline(
+		cli.ActionFunc(func(c *cli.Context) error {
+			return addPluginCommands(c, dirs)
+		}),
+		cli.HandleCommandNotFound(func(c *cli.Context, err error) (*cli.Command, error) {
+			return findPluginCommand(c, dirs, err)
+		}),
+	)
+}
+
+func addPluginCommands(c *cli.Context, dirs []string) error {
+	plugins := discoverPlugins(c.App().Name, dirs)
+	for _, name := range slices.Sorted(maps.Keys(plugins)) {
+		if _, exists := c.Command().Command(name); exists {
+			continue
+		}
+		if err := c.AddCommand(newPluginCommand(name, plugins[name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findPluginCommand(c *cli.Context, dirs []string, err error) (*cli.Command, error) {
+	var pe *cli.ParseError
+	if !errors.As(err, &pe) || pe.Name == "" {
+		return nil, err
+	}
+	plugins := discoverPlugins(c.App().Name, dirs)
+	if path, ok := plugins[pe.Name]; ok {
+		return newPluginCommand(pe.Name, path), nil
+	}
+	return nil, err
+}
+
+func newPluginCommand(name string, path string) *cli.Command {
+	return &cli.Command{
+		Name:     name,
+		HelpText: "Run the external command " + filepath.Base(path),
+		Category: PluginCategory,
+		Options:  cli.SkipFlagParsing,
+		Args: []*cli.Arg{
+			{
+				Name:    "args",
+				Value:   cli.List(),
+				NArg:    -1,
+				Options: cli.DisableSplitting,
+			},
+		},
+		Completion: cli.CompletionFunc(func(c *cli.Context) []cli.CompletionItem {
+			return completePlugin(c, path)
+		}),
+		Action: func(c *cli.Context) error {
+			cmd := eexec.CommandContext(c, path, c.List("args")...)
+			cmd.Env = append(os.Environ(), persistentFlagsEnv(c)...)
+			cmd.Stdin = c.Stdin
+			cmd.Stdout = c.Stdout
+			cmd.Stderr = c.Stderr
+			return cmd.Run()
+		},
+	}
+}
+
+// discoverPlugins finds the plugins for the app, mapping the command name to
+// the path of the executable
+func discoverPlugins(appName string, dirs []string) map[string]string {
+	prefix := appName + "-"
+	res := map[string]string{}
+	search := append(slices.Clone(dirs), filepath.SplitList(os.Getenv("PATH"))...)
+
+	for _, dir := range search {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			base, ok := pluginBaseName(dir, e)
+			if !ok {
+				continue
+			}
+			name, ok := strings.CutPrefix(base, prefix)
+			if !ok || name == "" {
+				continue
+			}
+			if _, exists := res[name]; !exists {
+				res[name] = filepath.Join(dir, e.Name())
+			}
+		}
+	}
+	return res
+}
+
+func persistentFlagsEnv(c *cli.Context) []string {
+	prefix := envSlug(c.App().Name)
+	var env []string
+	for _, ctx := range c.Lineage() {
+		cmd, ok := ctx.Target().(*cli.Command)
+		if !ok || ctx == c {
+			continue
+		}
+		for _, f := range cmd.Flags {
+			if f.Options&cli.NonPersistent == cli.NonPersistent || !ctx.Seen(f.Name) {
+				continue
+			}
+			env = append(env, prefix+"_"+envSlug(f.Name)+"="+envText(ctx.Value(f.Name)))
+		}
+	}
+	return env
+}
+
+func completePlugin(c *cli.Context, path string) []cli.CompletionItem {
+	req := c.CompletionRequest()
+	if req == nil {
+		return nil
+	}
+
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	words := []string{base}
+	if len(req.Args) > 0 {
+		words = append(words, req.Args[1:]...)
+	}
+	cword := len(words)
+	words = append(words, req.Incomplete)
+
+	var out bytes.Buffer
+	cmd := eexec.CommandContext(c, path)
+	cmd.Env = append(os.Environ(),
+		"COMP_WORDS="+cli.Join(words),
+		"COMP_CWORD="+strconv.Itoa(cword),
+		cli.CompletionEnvVar(base)+"=zsh",
+	)
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil
+	}
+	return parseCompletions(out.String())
+}
+
+// parseCompletions reads the completion items from the zsh completion
+// format, which provides the type, value, description, and space
+// indicator on consecutive lines
+func parseCompletions(s string) []cli.CompletionItem {
+	lines := strings.Split(s, "\n")
+	var res []cli.CompletionItem
+	for i := 0; i+3 < len(lines); i += 4 {
+		item := cli.CompletionItem{
+			Value:             lines[i+1],
+			PreventSpaceAfter: lines[i+3] == "",
+		}
+		switch lines[i] {
+		case "file":
+			item.Type = cli.CompletionTypeFile
+		case "dir":
+			item.Type = cli.CompletionTypeDirectory
+		}
+		if lines[i+2] != "_" {
+			item.HelpText = lines[i+2]
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func envSlug(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(s))
+}
+
+func envText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []string:
+		return strings.Join(t, ",")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/exec"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plugins", func() {
+
+	const script = `#!/bin/sh
+if [ -n "$_JOE_JOEPLUGINTESTHELLO_COMPLETE" ]; then
+	printf 'plain\n%s\nfrom plugin\n1\n' "$COMP_WORDS"
+	exit 0
+fi
+echo "args: $*"
+echo "verbose: $JOEPLUGINTEST_VERBOSE"
+`
+
+	var (
+		dir    string
+		stdout *bytes.Buffer
+		newApp func() *cli.App
+	)
+
+	BeforeEach(func() {
+		SkipOnWindows()
+
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "joeplugintest-hello"), []byte(script), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "joeplugintest-noexec"), []byte(script), 0o644)).To(Succeed())
+
+		stdout = new(bytes.Buffer)
+		newApp = func() *cli.App {
+			return &cli.App{
+				Name:   "joeplugintest",
+				Stdout: stdout,
+				Flags: []*cli.Flag{
+					{Name: "verbose", Value: new(bool)},
+				},
+				Commands: []*cli.Command{
+					{Name: "status"},
+				},
+				Uses: exec.Plugins(dir),
+			}
+		}
+	})
+
+	It("executes the plugin with arguments and persistent flags", func() {
+		args, _ := cli.Split("app --verbose hello a --b")
+		err := newApp().RunContext(context.Background(), args)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout.String()).To(Equal("args: a --b\nverbose: true\n"))
+	})
+
+	It("adds plugins as commands in the category", func() {
+		app := newApp()
+		_, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		cmd, ok := app.Command("hello")
+		Expect(ok).To(BeTrue())
+		Expect(cmd.Category).To(Equal(exec.PluginCategory))
+
+		_, ok = app.Command("noexec")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("suggests plugins", func() {
+		app := newApp()
+		args, _ := cli.Split("app helo")
+		err := app.RunContext(context.Background(), args)
+		Expect(err).To(HaveField("Suggestions", ContainElement("hello")))
+	})
+
+	It("forwards completion requests", func() {
+		app := newApp()
+		ctx, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		items := cli.FromContext(ctx).Complete([]string{"app", "hello", "x"}, "y")
+		Expect(items).To(Equal([]cli.CompletionItem{
+			{Value: "joeplugintest-hello x y", HelpText: "from plugin"},
+		}))
+	})
+})
@@ -0,0 +1,22 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package exec
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// pluginBaseName gets the name of the plugin executable if the entry is executable
+func pluginBaseName(dir string, e fs.DirEntry) (string, bool) {
+	info, err := os.Stat(filepath.Join(dir, e.Name()))
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	return e.Name(), true
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package exec
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pluginBaseName gets the name of the plugin executable without its extension if
+// the entry has one of the extensions listed in PATHEXT
+func pluginBaseName(_ string, e fs.DirEntry) (string, bool) {
+	if e.IsDir() {
+		return "", false
+	}
+	pathext := os.Getenv("PATHEXT")
+	if pathext == "" {
+		pathext = ".com;.exe;.bat;.cmd"
+	}
+	ext := filepath.Ext(e.Name())
+	for _, x := range filepath.SplitList(pathext) {
+		if ext != "" && strings.EqualFold(x, ext) {
+			return strings.TrimSuffix(e.Name(), ext), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+import (
+	"context"
+	"io"
+	"os"
+	eexec "os/exec"
+
+	"github.com/Carbonfrost/joe-cli"
+)
+
+// Shell is a pipeline-enabled value that runs a command line using the
+// OS-specific shell: sh -c on Unix-like systems and cmd /C on Windows.  On
+// Unix-like systems, Args are made available to the command line as the
+// positional parameters "$@"; on Windows, they are appended to the command line.
+//
+// The standard I/O of the command is connected to that of the context when
+// one is available; otherwise, the standard files of the process are used.
+type Shell struct {
+	// Command is the command line to be interpreted by the shell
+	Command string
+
+	// Args provides additional arguments passed to the command line
+	Args []string
+
+	// Cmd, when non-nil, is called with the *exec.Cmd created via
+	// exec.CommandContext before the shell is started, allowing further
+	// customization (environment variables, working directory, etc.).
+	Cmd func(*eexec.Cmd)
+}
+
+// Execute implements cli.Action.  It runs the command line and waits for it
+// to exit.
+func (s *Shell) Execute(ctx context.Context) error {
+	name, args := shellCommand(s.Command, s.Args)
+	cmd := eexec.CommandContext(ctx, name, args...)
+
+	var stdin io.Reader = os.Stdin
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	if c, ok := cli.TryFromContext(ctx); ok {
+		stdin, stdout, stderr = c.Stdin, c.Stdout, c.Stderr
+	}
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if s.Cmd != nil {
+		s.Cmd(cmd)
+	}
+	return cmd.Run()
+}
+
+var _ cli.Action = (*Shell)(nil)
@@ -0,0 +1,44 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	eexec "os/exec"
+
+	"github.com/Carbonfrost/joe-cli/extensions/exec"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shell", func() {
+
+	Describe("Execute", func() {
+
+		It("passes arguments as positional parameters", func() {
+			SkipOnWindows()
+			var out bytes.Buffer
+			s := exec.Shell{
+				Command: "echo hello",
+				Args:    []string{"a b", "c"},
+				Cmd: func(cmd *eexec.Cmd) {
+					cmd.Stdout = &out
+				},
+			}
+			Expect(s.Execute(context.Background())).To(Succeed())
+			Expect(out.String()).To(Equal("hello a b c\n"))
+		})
+
+		It("returns the exit error", func() {
+			SkipOnWindows()
+			s := exec.Shell{Command: "exit 3"}
+
+			err := s.Execute(context.Background())
+			Expect(err).To(BeAssignableToTypeOf(&eexec.ExitError{}))
+			Expect(err.(*eexec.ExitError).ExitCode()).To(Equal(3))
+		})
+	})
+})
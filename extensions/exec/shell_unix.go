@@ -0,0 +1,13 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package exec
+
+func shellCommand(command string, args []string) (string, []string) {
+	// The command line is followed by "$@" so that args are passed through as
+	// positional parameters.  $0 is set to the command line for diagnostics.
+	return "sh", append([]string{"-c", command + ` "$@"`, command}, args...)
+}
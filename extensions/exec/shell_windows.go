@@ -0,0 +1,13 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package exec
+
+import "strings"
+
+func shellCommand(command string, args []string) (string, []string) {
+	return "cmd", []string{"/C", strings.Join(append([]string{command}, args...), " ")}
+}
@@ -6,6 +6,9 @@ package expr
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/Carbonfrost/joe-cli"
 )
@@ -27,8 +30,8 @@ func SetEvaluator(e Evaluator) cli.Action {
 // AddExpr will add an expression operator to the containing Expression
 func AddExpr(e *Expr) cli.Action {
 	return cli.ActionFunc(func(c *cli.Context) error {
-		return updateExprs(c, func(ee []*Expr) []*Expr {
-			return append(ee, e)
+		return updateExprs(c, func(ee []*Expr) ([]*Expr, error) {
+			return append(ee, e), nil
 		})
 	})
 }
@@ -36,18 +39,32 @@ func AddExpr(e *Expr) cli.Action {
 // AddExprs will add multiple expression operators to the containing Expression
 func AddExprs(exprs ...*Expr) cli.Action {
 	return cli.ActionFunc(func(c *cli.Context) error {
-		return updateExprs(c, func(ee []*Expr) []*Expr {
-			return append(ee, exprs...)
+		return updateExprs(c, func(ee []*Expr) ([]*Expr, error) {
+			return append(ee, exprs...), nil
 		})
 	})
 }
 
-func updateExprs(c *cli.Context, fn func([]*Expr) []*Expr) error {
+type exprRegistration struct {
+	arg string
+	fn  func() *Expr
+}
+
+var registry struct {
+	sync.RWMutex
+	exprs []exprRegistration
+}
+
+func updateExprs(c *cli.Context, fn func([]*Expr) ([]*Expr, error)) error {
 	if err := requireInit(c); err != nil {
 		return err
 	}
 	exp := c.Arg().Value.(*Expression)
-	exp.Exprs = fn(exp.Exprs)
+	exprs, err := fn(exp.Exprs)
+	if err != nil {
+		return newInternalError(c, err)
+	}
+	exp.Exprs = exprs
 	return nil
 }
 
@@ -61,3 +78,45 @@ func requireInit(c *cli.Context) error {
 func newInternalError(c *cli.Context, err error) error {
 	return &cli.InternalError{Path: c.Path(), Timing: c.Timing(), Err: err}
 }
+
+// RegisterExpr registers an expression operator to be added to the Expression args
+// which use MountRegistered.  This allows packages to contribute operators without
+// the package defining the app importing them explicitly.  Typically, RegisterExpr is
+// called from the init function of the contributing package.  The arg is the name of
+// the Expression arg which contains the operator.  The function is called to create
+// the operator each time it is mounted, so that apps and repeated runs don't share it.
+func RegisterExpr(arg string, fn func() *Expr) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.exprs = append(registry.exprs, exprRegistration{arg: arg, fn: fn})
+}
+
+// MountRegistered provides an action used in the Uses pipeline of an Expression arg
+// which adds the expression operators registered with RegisterExpr for the arg.  If an
+// operator with the same name or alias already exists, an InternalError is returned.
+func MountRegistered() cli.Action {
+	return cli.ActionFunc(func(c *cli.Context) error {
+		registry.RLock()
+		exprs := slices.Clone(registry.exprs)
+		registry.RUnlock()
+
+		name := c.Arg().Name
+		return updateExprs(c, func(ee []*Expr) ([]*Expr, error) {
+			for _, r := range exprs {
+				if r.arg != name {
+					continue
+				}
+				e := r.fn()
+				for _, existing := range ee {
+					if slices.ContainsFunc(e.Names(), func(n string) bool {
+						return slices.Contains(existing.Names(), n)
+					}) {
+						return nil, fmt.Errorf("registered expression %q conflicts with existing expression %q", e.Name, existing.Name)
+					}
+				}
+				ee = append(ee, e)
+			}
+			return ee, nil
+		})
+	})
+}
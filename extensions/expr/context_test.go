@@ -79,3 +79,44 @@ var _ = Describe("AddExpr", func() {
 		Expect(app.Args[0].Value.(*expr.Expression).Exprs).To(HaveLen(2))
 	})
 })
+
+var _ = Describe("RegisterExpr", func() {
+
+	BeforeEach(func() {
+		expr.ResetRegistry()
+		DeferCleanup(expr.ResetRegistry)
+	})
+
+	newApp := func() *cli.App {
+		return &cli.App{
+			Args: []*cli.Arg{
+				{
+					Name:  "e",
+					Value: &expr.Expression{Exprs: []*expr.Expr{{Name: "print"}}},
+					Uses:  expr.MountRegistered(),
+				},
+			},
+		}
+	}
+
+	It("mounts registered exprs on the arg", func() {
+		expr.RegisterExpr("e", func() *expr.Expr { return &expr.Expr{Name: "size"} })
+		expr.RegisterExpr("other", func() *expr.Expr { return &expr.Expr{Name: "type"} })
+
+		first, second := newApp(), newApp()
+		Expect(first.Initialize(context.Background())).Error().NotTo(HaveOccurred())
+		Expect(second.Initialize(context.Background())).Error().NotTo(HaveOccurred())
+
+		exprs := first.Args[0].Value.(*expr.Expression).Exprs
+		Expect(exprs).To(HaveLen(2))
+		Expect(exprs[1].Name).To(Equal("size"))
+		Expect(exprs[1]).NotTo(BeIdenticalTo(second.Args[0].Value.(*expr.Expression).Exprs[1]))
+	})
+
+	It("returns an error when the expr conflicts", func() {
+		expr.RegisterExpr("e", func() *expr.Expr { return &expr.Expr{Name: "p", Aliases: []string{"print"}} })
+
+		_, err := newApp().Initialize(context.Background())
+		Expect(err).To(MatchError(ContainSubstring(`registered expression "p" conflicts with existing expression "print"`)))
+	})
+})
@@ -7,3 +7,9 @@ package expr // intentional
 func IsVisible(e *Expr) bool {
 	return !e.internalFlags().hidden()
 }
+
+func ResetRegistry() {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.exprs = nil
+}
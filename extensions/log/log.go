@@ -16,6 +16,9 @@
 // which take a context obtain it from the context; those which don't bridge to
 // the current app in order to find it.  When there is no default logger, they
 // fall back to the [slog] default.
+//
+// Secret values (see cli.Secret) are masked by the handlers automatically
+// because they implement [slog.LogValuer].
 package log
 
 import (
@@ -56,6 +56,21 @@ var _ = Describe("Logger", func() {
 			),
 		)
 
+		DescribeTable("masks secret values", func(format log.LogFormat) {
+			var buf bytes.Buffer
+			secret := cli.Secret()
+			_ = secret.Set("hunter2")
+
+			l := log.New(log.WithLogFormat(format), log.WithOutput(&buf))
+			l.Info("login", "token", secret)
+
+			Expect(buf.String()).To(ContainSubstring(cli.Redacted))
+			Expect(buf.String()).NotTo(ContainSubstring("hunter2"))
+		},
+			Entry("text", log.TextFormat),
+			Entry("JSON", log.JSONFormat),
+		)
+
 		It("applies options which are added after the logger is used", func() {
 			var buf bytes.Buffer
 			l := log.New(log.WithOutput(&buf))
@@ -176,13 +176,23 @@ func (c *converter) newArgMarshal(v *cli.Arg) Arg {
 
 func (c *converter) newValueMarshal(v any) Value {
 	typ := TypeFromValue(v)
-	if typ != UnknownType {
-		return Value{
-			Type:   &typ,
-			String: sprintValue(v),
+	if typ == UnknownType {
+		return Value{}
+	}
+	res := Value{
+		Type:   &typ,
+		String: sprintValue(v),
+	}
+	if key, elem, ok := ElemTypesFromValue(v); ok {
+		if key != UnknownType {
+			res.Key = &key
 		}
+		res.Elem = &elem
 	}
-	return Value{}
+	if e, ok := v.(enumValue); ok {
+		res.Enum = e.EnumNames()
+	}
+	return res
 }
 
 func (c *converter) newExpressionMarshal(v *expr.Expression) *Expression {
@@ -212,6 +212,9 @@ var _ = Describe("From", func() {
 			"Value": MatchFields(IgnoreUnexportedExtras, Fields{
 				"String": Equal("0"),
 				"Type":   Equal(new(marshal.Int)),
+				"Key":    BeNil(),
+				"Elem":   BeNil(),
+				"Enum":   BeNil(),
 			}),
 		}),
 			`{
@@ -265,6 +268,9 @@ var _ = Describe("From", func() {
 			"Value": MatchFields(IgnoreUnexportedExtras, Fields{
 				"String": Equal(""),
 				"Type":   BeNil(),
+				"Key":    BeNil(),
+				"Elem":   BeNil(),
+				"Enum":   BeNil(),
 			}),
 		}),
 			`{
@@ -337,6 +343,26 @@ var _ = Describe("From", func() {
        }`),
 	)
 
+	It("masks secret values", func() {
+		secret := cli.Secret()
+		_ = secret.Set("hunter2")
+		m := marshal.From(&cli.Flag{Name: "token", Value: secret}).(marshal.Flag)
+		Expect(m.Value.Type).To(Equal(new(marshal.Secret)))
+		Expect(m.Value.String).To(Equal(cli.Redacted))
+
+		data, _ := json.Marshal(m)
+		Expect(string(data)).NotTo(ContainSubstring("hunter2"))
+	})
+
+	It("lists enum names", func() {
+		m := marshal.From(&cli.Flag{
+			Name:  "format",
+			Value: cli.EnumValue(map[string]int{"json": 1, "text": 2}),
+		}).(marshal.Flag)
+		Expect(m.Value.Type).To(Equal(new(marshal.Enum)))
+		Expect(m.Value.Enum).To(Equal([]string{"json", "text"}))
+	})
+
 	Describe("clean marshal data dictionary", func() {
 
 		Describe("filtering by access", func() {
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
 	"regexp"
 	"strings"
@@ -91,6 +92,17 @@ type Arg struct {
 type Value struct {
 	Type   *BuiltinType `json:"type,omitempty"`
 	String string       `json:"string,omitempty"`
+
+	// Key is the type of keys when Type is Map and the value is a generic
+	// collection (see cli.MapOf)
+	Key *BuiltinType `json:"key,omitempty"`
+
+	// Elem is the type of elements when Type is List, Set, or Map and the value
+	// is a generic collection (see cli.ListOf, cli.SetOf, and cli.MapOf)
+	Elem *BuiltinType `json:"elem,omitempty"`
+
+	// Enum lists the names that are allowed when Type is Enum
+	Enum []string `json:"enum,omitempty"`
 }
 
 // Expression provides a representation of expressions
@@ -129,27 +141,43 @@ type BuiltinType int
 const (
 	UnknownType BuiltinType = iota
 
+	Addr
+	AddrPort
 	BigFloat
 	BigInt
 	Bool
 	Bytes
+	ByteSize
 	Duration
+	Enum
 	File
 	FileSet
 	Float32
 	Float64
+	HardwareAddr
+	HostPort
 	Int
 	Int16
 	Int32
 	Int64
 	Int8
 	IP
+	IPNet
+	IPRange
 	List
 	Map
 	NameValue
 	NameValues
+	Percent
+	Rate
 	Regexp
+	Secret
+	SemVer
+	SemVerConstraint
+	Set
+	SI
 	String
+	Time
 	Uint
 	Uint16
 	Uint32
@@ -163,40 +191,72 @@ const (
 var (
 	typeStrings = [maxType]string{
 		"",
-		BigFloat:   "bigfloat",
-		BigInt:     "bigint",
-		Bool:       "bool",
-		Bytes:      "bytes",
-		Duration:   "duration",
-		File:       "file",
-		FileSet:    "fileset",
-		Float32:    "float32",
-		Float64:    "float64",
-		Int:        "int",
-		Int16:      "int16",
-		Int32:      "int32",
-		Int64:      "int64",
-		Int8:       "int8",
-		IP:         "ip",
-		List:       "list",
-		Map:        "map",
-		NameValue:  "namevalue",
-		NameValues: "namevalues",
-		Regexp:     "regexp",
-		String:     "string",
-		Uint:       "uint",
-		Uint16:     "uint16",
-		Uint32:     "uint32",
-		Uint64:     "uint64",
-		Uint8:      "uint8",
-		URL:        "url",
+		Addr:             "addr",
+		AddrPort:         "addrport",
+		BigFloat:         "bigfloat",
+		BigInt:           "bigint",
+		Bool:             "bool",
+		Bytes:            "bytes",
+		ByteSize:         "bytesize",
+		Duration:         "duration",
+		Enum:             "enum",
+		File:             "file",
+		FileSet:          "fileset",
+		Float32:          "float32",
+		Float64:          "float64",
+		HardwareAddr:     "hardwareaddr",
+		HostPort:         "hostport",
+		Int:              "int",
+		Int16:            "int16",
+		Int32:            "int32",
+		Int64:            "int64",
+		Int8:             "int8",
+		IP:               "ip",
+		IPNet:            "ipnet",
+		IPRange:          "iprange",
+		List:             "list",
+		Map:              "map",
+		NameValue:        "namevalue",
+		NameValues:       "namevalues",
+		Percent:          "percent",
+		Rate:             "rate",
+		Regexp:           "regexp",
+		Secret:           "secret",
+		SemVer:           "semver",
+		SemVerConstraint: "semverconstraint",
+		Set:              "set",
+		SI:               "si",
+		String:           "string",
+		Time:             "time",
+		Uint:             "uint",
+		Uint16:           "uint16",
+		Uint32:           "uint32",
+		Uint64:           "uint64",
+		Uint8:            "uint8",
+		URL:              "url",
 	}
 )
 
+type enumValue interface {
+	EnumNames() []string
+}
+
 // TypeFromValue gets the Type for the given value. The value can be a built-in supported
 // flag type or a pointer to one. If none matches, unknownType is returned.
+// Enum values (see cli.EnumValue) are Enum.
+// Generic collections are List, Set, or Map depending upon their kind; the types of
+// their keys and elements are obtained using ElemTypesFromValue.
 func TypeFromValue(v any) BuiltinType {
-	switch v.(type) {
+	switch t := v.(type) {
+	case cli.CollectionValue:
+		switch t.CollectionKind() {
+		case cli.ListCollection:
+			return List
+		case cli.SetCollection:
+			return Set
+		case cli.MapCollection:
+			return Map
+		}
 	case *bool, bool:
 		return Bool
 	case *string, string:
@@ -229,6 +289,16 @@ func TypeFromValue(v any) BuiltinType {
 		return Float64
 	case *time.Duration, time.Duration:
 		return Duration
+	case *cli.TimeValue, time.Time:
+		return Time
+	case *cli.SecretValue:
+		return Secret
+	case *cli.SemVerValue, cli.Version:
+		return SemVer
+	case *cli.SemVerConstraintValue, cli.VersionConstraint:
+		return SemVerConstraint
+	case enumValue:
+		return Enum
 	case *map[string]string, map[string]string:
 		return Map
 	case *[]*cli.NameValue, []*cli.NameValue:
@@ -237,6 +307,26 @@ func TypeFromValue(v any) BuiltinType {
 		return URL
 	case *net.IP, net.IP:
 		return IP
+	case *cli.IPNetValue, netip.Prefix:
+		return IPNet
+	case *cli.AddrValue, netip.Addr:
+		return Addr
+	case *cli.AddrPortValue, netip.AddrPort:
+		return AddrPort
+	case *cli.HostPortValue:
+		return HostPort
+	case *cli.HardwareAddrValue, net.HardwareAddr:
+		return HardwareAddr
+	case *cli.IPRangeValue:
+		return IPRange
+	case *cli.ByteSizeValue:
+		return ByteSize
+	case *cli.PercentValue:
+		return Percent
+	case *cli.SIValue:
+		return SI
+	case *cli.Rate:
+		return Rate
 	case **regexp.Regexp, *regexp.Regexp:
 		return Regexp
 	case **big.Int, *big.Int:
@@ -256,6 +346,20 @@ func TypeFromValue(v any) BuiltinType {
 	return UnknownType
 }
 
+// ElemTypesFromValue gets the types of the keys and elements of a generic collection
+// value.  For lists and sets, key is UnknownType.  If the value is not a generic collection,
+// ok is false.
+func ElemTypesFromValue(v any) (key, elem BuiltinType, ok bool) {
+	c, ok := v.(cli.CollectionValue)
+	if !ok {
+		return UnknownType, UnknownType, false
+	}
+	if k := c.NewKey(); k != nil {
+		key = TypeFromValue(k)
+	}
+	return key, TypeFromValue(c.NewElem()), true
+}
+
 func (t BuiltinType) New() any {
 	switch t {
 	case NameValues:
@@ -288,12 +392,44 @@ func (t BuiltinType) New() any {
 		return cli.Map()
 	case IP:
 		return cli.IP()
+	case IPNet:
+		return cli.IPNet()
+	case IPRange:
+		return cli.IPRange()
+	case Addr:
+		return cli.Addr()
+	case AddrPort:
+		return cli.AddrPort()
+	case HostPort:
+		return cli.HostPort(0)
+	case HardwareAddr:
+		return cli.HardwareAddr()
+	case ByteSize:
+		return cli.ByteSize()
+	case Percent:
+		return cli.Percent()
+	case SI:
+		return cli.SI()
+	case Rate:
+		return new(cli.Rate)
 	case Regexp:
 		return cli.Regexp()
+	case Secret:
+		return cli.Secret()
+	case SemVer:
+		return cli.SemVer()
+	case SemVerConstraint:
+		return cli.SemVerConstraint()
+	case Set:
+		return cli.SetOf[string]()
 	case String:
 		return cli.String()
 	case Duration:
 		return cli.Duration()
+	case Enum:
+		return cli.EnumValue[string](nil)
+	case Time:
+		return cli.Time()
 	case Uint:
 		return cli.Uint()
 	case Uint16:
@@ -6,6 +6,7 @@ package marshal_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/Carbonfrost/joe-cli"
 	"github.com/Carbonfrost/joe-cli/extensions/marshal"
@@ -34,6 +35,7 @@ var _ = Describe("BuiltinType", func() {
 			Entry("Int64", marshal.Int64, cli.Int64()),
 			Entry("Int8", marshal.Int8, cli.Int8()),
 			Entry("Duration", marshal.Duration, cli.Duration()),
+			Entry("Enum", marshal.Enum, cli.EnumValue[string](nil)),
 			Entry("List", marshal.List, cli.List()),
 			Entry("Map", marshal.Map, cli.Map()),
 			Entry("NameValue", marshal.NameValue, &cli.NameValue{}),
@@ -46,7 +48,22 @@ var _ = Describe("BuiltinType", func() {
 			Entry("Uint8", marshal.Uint8, cli.Uint8()),
 			Entry("URL", marshal.URL, cli.URL()),
 			Entry("Regexp", marshal.Regexp, cli.Regexp()),
+			Entry("Secret", marshal.Secret, cli.Secret()),
+			Entry("SemVer", marshal.SemVer, cli.SemVer()),
+			Entry("SemVerConstraint", marshal.SemVerConstraint, cli.SemVerConstraint()),
+			Entry("Set", marshal.Set, cli.SetOf[string]()),
+			Entry("Time", marshal.Time, cli.Time()),
 			Entry("IP", marshal.IP, cli.IP()),
+			Entry("IPNet", marshal.IPNet, cli.IPNet()),
+			Entry("IPRange", marshal.IPRange, cli.IPRange()),
+			Entry("Addr", marshal.Addr, cli.Addr()),
+			Entry("AddrPort", marshal.AddrPort, cli.AddrPort()),
+			Entry("HostPort", marshal.HostPort, cli.HostPort(0)),
+			Entry("HardwareAddr", marshal.HardwareAddr, cli.HardwareAddr()),
+			Entry("ByteSize", marshal.ByteSize, cli.ByteSize()),
+			Entry("Percent", marshal.Percent, cli.Percent()),
+			Entry("SI", marshal.SI, cli.SI()),
+			Entry("Rate", marshal.Rate, &cli.Rate{}),
 			Entry("BigFloat", marshal.BigFloat, cli.BigFloat()),
 			Entry("BigInt", marshal.BigInt, cli.BigInt()),
 		)
@@ -90,6 +107,27 @@ var _ = Describe("BuiltinType", func() {
 	})
 })
 
+var _ = Describe("ElemTypesFromValue", func() {
+
+	DescribeTable("examples",
+		func(v any, expectedType, expectedKey, expectedElem marshal.BuiltinType) {
+			key, elem, ok := marshal.ElemTypesFromValue(v)
+			Expect(ok).To(BeTrue())
+			Expect(marshal.TypeFromValue(v)).To(Equal(expectedType))
+			Expect(key).To(Equal(expectedKey))
+			Expect(elem).To(Equal(expectedElem))
+		},
+		Entry("list", cli.ListOf[int](), marshal.List, marshal.UnknownType, marshal.Int),
+		Entry("set", cli.SetOf[string](), marshal.Set, marshal.UnknownType, marshal.String),
+		Entry("map", cli.MapOf[string, time.Duration](), marshal.Map, marshal.String, marshal.Duration),
+	)
+
+	It("is not ok for other values", func() {
+		_, _, ok := marshal.ElemTypesFromValue(cli.List())
+		Expect(ok).To(BeFalse())
+	})
+})
+
 var _ = Describe("Schema", func() {
 
 	Describe("UnmarshalJSON", func() {
@@ -62,9 +62,16 @@ func (c *OutputContext) File(name string) string {
 	return filepath.Join(c.WorkDir(), c.expandName(name))
 }
 
-// WorkDir is the path to the working directory
+// WorkDir is the path to the working directory.  A directory which was pushed
+// using an absolute path replaces the directories pushed before it.
 func (c *OutputContext) WorkDir() string {
-	return filepath.Clean(filepath.Join(c.working...))
+	working := c.working
+	for i, dir := range working {
+		if filepath.IsAbs(dir) {
+			working = c.working[i:]
+		}
+	}
+	return filepath.Clean(filepath.Join(working...))
 }
 
 func (c *OutputContext) PushDir(name string) error {
@@ -73,6 +73,7 @@ type Flag struct {
 	EnvVars []string
 
 	// FilePath specifies a file that is loaded to provide the default value of the flag.
+	// Trailing newlines are removed from the contents of the file.
 	FilePath string
 
 	// Value provides the value of the flag.  Any of the following types are valid for the
@@ -156,7 +157,8 @@ type Flag struct {
 	// unless PersistentIn was used.
 	persistentIn ContextFilter
 
-	bs BindingState
+	bs     BindingState
+	source *ValueSource
 }
 
 type flagsByCategory []*flagCategory
@@ -180,6 +182,8 @@ type option interface {
 	name() string
 	envVars() []string
 	filePath() string
+	valueSource() *ValueSource
+	setValueSource(*ValueSource)
 	setTransform(fn TransformFunc)
 }
 
@@ -559,6 +563,14 @@ func (f *Flag) filePath() string {
 	return f.FilePath
 }
 
+func (f *Flag) valueSource() *ValueSource {
+	return f.source
+}
+
+func (f *Flag) setValueSource(s *ValueSource) {
+	f.source = s
+}
+
 func (f *Flag) value() any {
 	var created bool
 	f.Value, created = ensureDestination(f.Value, false)
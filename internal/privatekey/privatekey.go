@@ -5,9 +5,12 @@ type T string
 const (
 	ShellCompletes    = "__ShellCompletes"
 	CommandNotFound   = "__CommandNotFound"
+	CommandAlias      = "__CommandAlias"
 	Synopsis          = "_Synopsis"
 	CompletionRequest = "_CompletionRequest"
 	PanicData         = "__PanicData"
 	OptionalAliases   = "__OptionalAliases"
 	DependsOn         = "__DependsOn"
+	LookupEnv         = "__LookupEnv"
+	ValueSources      = "__ValueSources"
 )
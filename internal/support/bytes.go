@@ -0,0 +1,86 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package support
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	byteSizePattern = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*(?:([kKmMgGtTpPeEzZyYrRqQ])(i?))?([bB]?)$`)
+
+	// bytePrefixes are the prefixes of decimal byte units in order of magnitude
+	bytePrefixes = []string{"", "k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q"}
+)
+
+// ParseByteSize parses a number of bytes which can use a decimal (kB, MB, GB, ...)
+// or binary (KiB, MiB, GiB, ...) unit.  The prefix is case-insensitive.  The unit B
+// denotes bytes and is optional, whereas the unit b denotes bits, which are
+// converted to bytes.  The result is an error if it isn't a whole number of bytes.
+func ParseByteSize(s string) (float64, error) {
+	m := byteSizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrSyntax}
+	}
+	num, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrSyntax}
+	}
+
+	base := 1000.0
+	if m[3] != "" {
+		base = 1024
+	}
+	var exp int
+	for i, p := range bytePrefixes {
+		if m[2] != "" && strings.EqualFold(p, m[2]) {
+			exp = i
+		}
+	}
+
+	res := num * math.Pow(base, float64(exp))
+	if m[4] == "b" {
+		res /= 8
+	}
+
+	// Allow for imprecision from floating point arithmetic
+	rounded := math.Round(res)
+	if math.Abs(res-rounded) > 1e-6*math.Max(1, rounded) {
+		return 0, &strconv.NumError{Func: "ParseByteSize", Num: s, Err: strconv.ErrSyntax}
+	}
+	return rounded, nil
+}
+
+// FormatByteSize formats the number of bytes using the largest decimal or binary
+// unit which represents it with at most two decimal places
+func FormatByteSize(v int64) string {
+	if v == 0 {
+		return "0B"
+	}
+	best, bestExp := strconv.FormatInt(v, 10)+"B", 0
+	for _, binary := range []bool{true, false} {
+		base := 1000.0
+		if binary {
+			base = 1024
+		}
+		for exp := 6; exp > bestExp; exp-- {
+			x := float64(v) / math.Pow(base, float64(exp))
+			text := strconv.FormatFloat(x, 'f', -1, 64)
+			if _, frac, _ := strings.Cut(text, "."); len(frac) > 2 || math.Abs(x) < 1 {
+				continue
+			}
+			unit := bytePrefixes[exp]
+			if binary {
+				unit = strings.ToUpper(unit) + "i"
+			}
+			best, bestExp = text+unit+"B", exp
+			break
+		}
+	}
+	return best
+}
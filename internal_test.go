@@ -19,6 +19,30 @@ func SetOSExit(fn func(int)) {
 	osExit = fn
 }
 
+// ResetRegistry clears commands and flags registered with RegisterCommand and RegisterFlag
+func ResetRegistry() {
+	globals.Lock()
+	defer globals.Unlock()
+	globals.registry = nil
+}
+
+// InteractiveShellComplete gets the result of pressing Tab at the end of the line
+// in the interactive shell
+func InteractiveShellComplete(c *Context, line string) string {
+	r := newREPL(c)
+	res, _, ok := r.autoComplete(line, len(line), '\t')
+	if !ok {
+		return line
+	}
+	return res
+}
+
+// CommonCompletion gets the longest common prefix of the token completions
+func CommonCompletion(items []CompletionItem) string {
+	res, _ := commonCompletion(items)
+	return res
+}
+
 func IsVisible(t any) bool {
 	return !t.(target).internalFlags().hidden()
 }
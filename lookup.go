@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -41,6 +42,12 @@ type Lookup interface {
 	Int8(name any) int8
 	// Duration obtains the value and converts it to a Duration
 	Duration(name any) time.Duration
+	// Time obtains the value and converts it to a Time
+	Time(name any) time.Time
+	// SemVer obtains the value and converts it to a Version
+	SemVer(name any) Version
+	// SemVerConstraint obtains the value and converts it to a VersionConstraint
+	SemVerConstraint(name any) VersionConstraint
 	// List obtains the value and converts it to a slice of strings
 	List(name any) []string
 	// Map obtains the value and converts it to a map
@@ -69,6 +76,26 @@ type Lookup interface {
 	Regexp(name any) *regexp.Regexp
 	// IP obtains the value and converts it to a IP
 	IP(name any) net.IP
+	// IPNet obtains the value and converts it to a network in CIDR notation
+	IPNet(name any) netip.Prefix
+	// Addr obtains the value and converts it to a IP address
+	Addr(name any) netip.Addr
+	// AddrPort obtains the value and converts it to a IP address and port
+	AddrPort(name any) netip.AddrPort
+	// HostPort obtains the value and converts it to a host and port
+	HostPort(name any) string
+	// HardwareAddr obtains the value and converts it to a hardware address
+	HardwareAddr(name any) net.HardwareAddr
+	// IPRange obtains the value and converts it to a IP range
+	IPRange(name any) *IPRangeValue
+	// ByteSize obtains the value and converts it to a byte size
+	ByteSize(name any) int64
+	// Percent obtains the value and converts it to a percentage
+	Percent(name any) float64
+	// SI obtains the value and converts it to a number with an SI prefix
+	SI(name any) float64
+	// Rate obtains the value and converts it to a rate
+	Rate(name any) *Rate
 	// BigInt obtains the value and converts it to a BigInt
 	BigInt(name any) *big.Int
 	// BigFloat obtains the value and converts it to a BigFloat
@@ -194,6 +221,21 @@ func (c LookupValues) Duration(name any) time.Duration {
 	return lookupDuration(c, name)
 }
 
+// Time obtains the Time for the specified name
+func (c LookupValues) Time(name any) time.Time {
+	return lookupTime(c, name)
+}
+
+// SemVer obtains the Version for the specified name
+func (c LookupValues) SemVer(name any) Version {
+	return lookupSemVer(c, name)
+}
+
+// SemVerConstraint obtains the VersionConstraint for the specified name
+func (c LookupValues) SemVerConstraint(name any) VersionConstraint {
+	return lookupSemVerConstraint(c, name)
+}
+
 // File obtains the File for the specified name
 func (c LookupValues) File(name any) *File {
 	return lookupFile(c, name)
@@ -234,6 +276,56 @@ func (c LookupValues) IP(name any) net.IP {
 	return lookupIP(c, name)
 }
 
+// IPNet obtains the network in CIDR notation for the specified name
+func (c LookupValues) IPNet(name any) netip.Prefix {
+	return lookupIPNet(c, name)
+}
+
+// Addr obtains the IP address for the specified name
+func (c LookupValues) Addr(name any) netip.Addr {
+	return lookupAddr(c, name)
+}
+
+// AddrPort obtains the IP address and port for the specified name
+func (c LookupValues) AddrPort(name any) netip.AddrPort {
+	return lookupAddrPort(c, name)
+}
+
+// HostPort obtains the host and port for the specified name
+func (c LookupValues) HostPort(name any) string {
+	return lookupHostPort(c, name)
+}
+
+// HardwareAddr obtains the hardware address for the specified name
+func (c LookupValues) HardwareAddr(name any) net.HardwareAddr {
+	return lookupHardwareAddr(c, name)
+}
+
+// IPRange obtains the IP range for the specified name
+func (c LookupValues) IPRange(name any) *IPRangeValue {
+	return lookupIPRange(c, name)
+}
+
+// ByteSize obtains the byte size for the specified name
+func (c LookupValues) ByteSize(name any) int64 {
+	return lookupByteSize(c, name)
+}
+
+// Percent obtains the percentage for the specified name
+func (c LookupValues) Percent(name any) float64 {
+	return lookupPercent(c, name)
+}
+
+// SI obtains the number with an SI prefix for the specified name
+func (c LookupValues) SI(name any) float64 {
+	return lookupSI(c, name)
+}
+
+// Rate obtains the rate for the specified name
+func (c LookupValues) Rate(name any) *Rate {
+	return lookupRate(c, name)
+}
+
 // BigInt obtains the BigInt for the specified name
 func (c LookupValues) BigInt(name any) *big.Int {
 	return lookupBigInt(c, name)
@@ -334,6 +426,21 @@ func (c LookupFunc) Duration(name any) time.Duration {
 	return lookupDuration(c, name)
 }
 
+// Time retrieves the value and coerces it to the return type
+func (c LookupFunc) Time(name any) time.Time {
+	return lookupTime(c, name)
+}
+
+// SemVer retrieves the value and coerces it to the return type
+func (c LookupFunc) SemVer(name any) Version {
+	return lookupSemVer(c, name)
+}
+
+// SemVerConstraint retrieves the value and coerces it to the return type
+func (c LookupFunc) SemVerConstraint(name any) VersionConstraint {
+	return lookupSemVerConstraint(c, name)
+}
+
 // File retrieves the value and coerces it to the return type
 func (c LookupFunc) File(name any) *File {
 	return lookupFile(c, name)
@@ -374,6 +481,56 @@ func (c LookupFunc) IP(name any) net.IP {
 	return lookupIP(c, name)
 }
 
+// IPNet retrieves the value and coerces it to the return type
+func (c LookupFunc) IPNet(name any) netip.Prefix {
+	return lookupIPNet(c, name)
+}
+
+// Addr retrieves the value and coerces it to the return type
+func (c LookupFunc) Addr(name any) netip.Addr {
+	return lookupAddr(c, name)
+}
+
+// AddrPort retrieves the value and coerces it to the return type
+func (c LookupFunc) AddrPort(name any) netip.AddrPort {
+	return lookupAddrPort(c, name)
+}
+
+// HostPort retrieves the value and coerces it to the return type
+func (c LookupFunc) HostPort(name any) string {
+	return lookupHostPort(c, name)
+}
+
+// HardwareAddr retrieves the value and coerces it to the return type
+func (c LookupFunc) HardwareAddr(name any) net.HardwareAddr {
+	return lookupHardwareAddr(c, name)
+}
+
+// IPRange retrieves the value and coerces it to the return type
+func (c LookupFunc) IPRange(name any) *IPRangeValue {
+	return lookupIPRange(c, name)
+}
+
+// ByteSize retrieves the value and coerces it to the return type
+func (c LookupFunc) ByteSize(name any) int64 {
+	return lookupByteSize(c, name)
+}
+
+// Percent retrieves the value and coerces it to the return type
+func (c LookupFunc) Percent(name any) float64 {
+	return lookupPercent(c, name)
+}
+
+// SI retrieves the value and coerces it to the return type
+func (c LookupFunc) SI(name any) float64 {
+	return lookupSI(c, name)
+}
+
+// Rate retrieves the value and coerces it to the return type
+func (c LookupFunc) Rate(name any) *Rate {
+	return lookupRate(c, name)
+}
+
 // BigInt retrieves the value and coerces it to the return type
 func (c LookupFunc) BigInt(name any) *big.Int {
 	return lookupBigInt(c, name)
@@ -436,35 +593,48 @@ func (c LookupFunc) lookupValue(name string) (any, bool) {
 	return c(name)
 }
 
-func (emptyLookup) Bool(any) bool               { return false }
-func (emptyLookup) File(any) *File              { return nil }
-func (emptyLookup) FileSet(any) *FileSet        { return nil }
-func (emptyLookup) Float32(any) float32         { return 0 }
-func (emptyLookup) Float64(any) float64         { return 0 }
-func (emptyLookup) Int(any) int                 { return 0 }
-func (emptyLookup) Int16(any) int16             { return 0 }
-func (emptyLookup) Int32(any) int32             { return 0 }
-func (emptyLookup) Int64(any) int64             { return 0 }
-func (emptyLookup) Int8(any) int8               { return 0 }
-func (emptyLookup) Duration(any) time.Duration  { return 0 }
-func (emptyLookup) List(any) []string           { return nil }
-func (emptyLookup) Map(any) map[string]string   { return nil }
-func (emptyLookup) NameValue(any) *NameValue    { return nil }
-func (emptyLookup) NameValues(any) []*NameValue { return nil }
-func (emptyLookup) String(any) string           { return "" }
-func (emptyLookup) Uint(any) uint               { return 0 }
-func (emptyLookup) Uint16(any) uint16           { return 0 }
-func (emptyLookup) Uint32(any) uint32           { return 0 }
-func (emptyLookup) Uint64(any) uint64           { return 0 }
-func (emptyLookup) Uint8(any) uint8             { return 0 }
-func (emptyLookup) URL(any) *url.URL            { return nil }
-func (emptyLookup) Regexp(any) *regexp.Regexp   { return nil }
-func (emptyLookup) IP(any) net.IP               { return nil }
-func (emptyLookup) BigInt(any) *big.Int         { return nil }
-func (emptyLookup) BigFloat(any) *big.Float     { return nil }
-func (emptyLookup) Bytes(any) []byte            { return nil }
-func (emptyLookup) Interface(any) (any, bool)   { return nil, false }
-func (emptyLookup) Value(any) any               { return nil }
+func (emptyLookup) Bool(any) bool                          { return false }
+func (emptyLookup) File(any) *File                         { return nil }
+func (emptyLookup) FileSet(any) *FileSet                   { return nil }
+func (emptyLookup) Float32(any) float32                    { return 0 }
+func (emptyLookup) Float64(any) float64                    { return 0 }
+func (emptyLookup) Int(any) int                            { return 0 }
+func (emptyLookup) Int16(any) int16                        { return 0 }
+func (emptyLookup) Int32(any) int32                        { return 0 }
+func (emptyLookup) Int64(any) int64                        { return 0 }
+func (emptyLookup) Int8(any) int8                          { return 0 }
+func (emptyLookup) Duration(any) time.Duration             { return 0 }
+func (emptyLookup) Time(any) time.Time                     { return time.Time{} }
+func (emptyLookup) SemVer(any) Version                     { return Version{} }
+func (emptyLookup) SemVerConstraint(any) VersionConstraint { return VersionConstraint{} }
+func (emptyLookup) List(any) []string                      { return nil }
+func (emptyLookup) Map(any) map[string]string              { return nil }
+func (emptyLookup) NameValue(any) *NameValue               { return nil }
+func (emptyLookup) NameValues(any) []*NameValue            { return nil }
+func (emptyLookup) String(any) string                      { return "" }
+func (emptyLookup) Uint(any) uint                          { return 0 }
+func (emptyLookup) Uint16(any) uint16                      { return 0 }
+func (emptyLookup) Uint32(any) uint32                      { return 0 }
+func (emptyLookup) Uint64(any) uint64                      { return 0 }
+func (emptyLookup) Uint8(any) uint8                        { return 0 }
+func (emptyLookup) URL(any) *url.URL                       { return nil }
+func (emptyLookup) Regexp(any) *regexp.Regexp              { return nil }
+func (emptyLookup) IP(any) net.IP                          { return nil }
+func (emptyLookup) IPNet(any) netip.Prefix                 { return netip.Prefix{} }
+func (emptyLookup) Addr(any) netip.Addr                    { return netip.Addr{} }
+func (emptyLookup) AddrPort(any) netip.AddrPort            { return netip.AddrPort{} }
+func (emptyLookup) HostPort(any) string                    { return "" }
+func (emptyLookup) HardwareAddr(any) net.HardwareAddr      { return nil }
+func (emptyLookup) IPRange(any) *IPRangeValue              { return nil }
+func (emptyLookup) ByteSize(any) int64                     { return 0 }
+func (emptyLookup) Percent(any) float64                    { return 0 }
+func (emptyLookup) SI(any) float64                         { return 0 }
+func (emptyLookup) Rate(any) *Rate                         { return nil }
+func (emptyLookup) BigInt(any) *big.Int                    { return nil }
+func (emptyLookup) BigFloat(any) *big.Float                { return nil }
+func (emptyLookup) Bytes(any) []byte                       { return nil }
+func (emptyLookup) Interface(any) (any, bool)              { return nil, false }
+func (emptyLookup) Value(any) any                          { return nil }
 
 func nameToString(name any) string {
 	switch v := name.(type) {
@@ -641,6 +811,30 @@ func lookupDuration(c Lookup, name any) (res time.Duration) {
 	return
 }
 
+func lookupTime(c Lookup, name any) (res time.Time) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(time.Time)
+	}
+	return
+}
+
+func lookupSemVer(c Lookup, name any) (res Version) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(Version)
+	}
+	return
+}
+
+func lookupSemVerConstraint(c Lookup, name any) (res VersionConstraint) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(VersionConstraint)
+	}
+	return
+}
+
 func lookupFile(c Lookup, name any) (res *File) {
 	val := c.Value(name)
 	if val != nil {
@@ -705,6 +899,86 @@ func lookupIP(c Lookup, name any) (res net.IP) {
 	return
 }
 
+func lookupIPNet(c Lookup, name any) (res netip.Prefix) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(netip.Prefix)
+	}
+	return
+}
+
+func lookupAddr(c Lookup, name any) (res netip.Addr) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(netip.Addr)
+	}
+	return
+}
+
+func lookupAddrPort(c Lookup, name any) (res netip.AddrPort) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(netip.AddrPort)
+	}
+	return
+}
+
+func lookupHostPort(c Lookup, name any) (res string) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(string)
+	}
+	return
+}
+
+func lookupHardwareAddr(c Lookup, name any) (res net.HardwareAddr) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(net.HardwareAddr)
+	}
+	return
+}
+
+func lookupIPRange(c Lookup, name any) (res *IPRangeValue) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(*IPRangeValue)
+	}
+	return
+}
+
+func lookupByteSize(c Lookup, name any) (res int64) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(int64)
+	}
+	return
+}
+
+func lookupPercent(c Lookup, name any) (res float64) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(float64)
+	}
+	return
+}
+
+func lookupSI(c Lookup, name any) (res float64) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(float64)
+	}
+	return
+}
+
+func lookupRate(c Lookup, name any) (res *Rate) {
+	val := c.Value(name)
+	if val != nil {
+		res = val.(*Rate)
+	}
+	return
+}
+
 func lookupBigInt(c Lookup, name any) (res *big.Int) {
 	val := c.Value(name)
 	if val != nil {
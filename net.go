@@ -0,0 +1,589 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"errors"
+	"iter"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IPNetValue provides a value which parses an IP network in CIDR notation
+// such as 10.0.0.0/8 or fd00::/64.
+type IPNetValue struct {
+	value netip.Prefix
+}
+
+// AddrValue provides a value which parses an IPv4 or IPv6 address.  Unlike
+// IP, the value is a netip.Addr.
+type AddrValue struct {
+	value netip.Addr
+}
+
+// AddrPortValue provides a value which parses an IP address and port such
+// as 10.0.0.1:80 or [::1]:80.
+type AddrPortValue struct {
+	value netip.AddrPort
+}
+
+// HostPortValue provides a value which parses a host name or IP address and
+// an optional port such as example.com:8080, example.com, or [::1]:8080.
+// When the port is omitted, the default port is used.
+type HostPortValue struct {
+	host        string
+	port        int
+	defaultPort int
+}
+
+// HardwareAddrValue provides a value which parses a hardware address
+// such as a MAC address using any of the formats supported by net.ParseMAC.
+type HardwareAddrValue struct {
+	value net.HardwareAddr
+}
+
+// IPRangeValue provides a value which is an inclusive range of IP addresses.
+// The range can be specified using two addresses separated by a hyphen such as
+// 10.0.0.1-10.0.0.20, as a network in CIDR notation such as 10.0.0.0/24 (which
+// denotes all of the addresses in the network), or as a single address.
+type IPRangeValue struct {
+	// From is the first address in the range
+	From netip.Addr
+	// To is the last address in the range
+	To netip.Addr
+}
+
+// IPNet creates an IP network value
+func IPNet() *IPNetValue {
+	return new(IPNetValue)
+}
+
+// Addr creates an IP address value
+func Addr() *AddrValue {
+	return new(AddrValue)
+}
+
+// AddrPort creates an IP address and port value
+func AddrPort() *AddrPortValue {
+	return new(AddrPortValue)
+}
+
+// HostPort creates a host and port value.  The default port is used when the
+// port is omitted.  If the default port is 0, then the port is required.
+func HostPort(defaultPort int) *HostPortValue {
+	return &HostPortValue{
+		defaultPort: defaultPort,
+	}
+}
+
+// HardwareAddr creates a hardware address value
+func HardwareAddr() *HardwareAddrValue {
+	return new(HardwareAddrValue)
+}
+
+// IPRange creates an IP range value
+func IPRange() *IPRangeValue {
+	return new(IPRangeValue)
+}
+
+// Set will parse the network
+func (n *IPNetValue) Set(arg string) error {
+	v, err := netip.ParsePrefix(strings.TrimSpace(arg))
+	if err != nil {
+		return errors.New("not a valid CIDR network")
+	}
+	n.value = v
+	return nil
+}
+
+// String gets the network in CIDR notation
+func (n *IPNetValue) String() string {
+	if !n.value.IsValid() {
+		return ""
+	}
+	return n.value.String()
+}
+
+// Value obtains the network
+func (n *IPNetValue) Value() netip.Prefix {
+	return n.value
+}
+
+// IPNet obtains the network converted to a *net.IPNet, which is nil if the
+// value is unset
+func (n *IPNetValue) IPNet() *net.IPNet {
+	if !n.value.IsValid() {
+		return nil
+	}
+	p := n.value.Masked()
+	return &net.IPNet{
+		IP:   net.IP(p.Addr().AsSlice()),
+		Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+	}
+}
+
+// Reset sets the value back to the zero value
+func (n *IPNetValue) Reset() {
+	n.value = netip.Prefix{}
+}
+
+// Copy creates a copy of the value
+func (n *IPNetValue) Copy() *IPNetValue {
+	res := *n
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*IPNetValue) Synopsis() string {
+	return "CIDR"
+}
+
+// Completion obtains the completion for the networks of the local network interfaces
+func (*IPNetValue) Completion() Completion {
+	return interfaceCompletion(func(p netip.Prefix) string {
+		return p.Masked().String()
+	}, false)
+}
+
+func (n *IPNetValue) setDirect(v any) error {
+	n.value = v.(netip.Prefix)
+	return nil
+}
+
+// Set will parse the address
+func (a *AddrValue) Set(arg string) error {
+	v, err := netip.ParseAddr(strings.TrimSpace(arg))
+	if err != nil {
+		return errors.New("not a valid IP address")
+	}
+	a.value = v
+	return nil
+}
+
+// String gets the address
+func (a *AddrValue) String() string {
+	if !a.value.IsValid() {
+		return ""
+	}
+	return a.value.String()
+}
+
+// Value obtains the address
+func (a *AddrValue) Value() netip.Addr {
+	return a.value
+}
+
+// Reset sets the value back to the zero value
+func (a *AddrValue) Reset() {
+	a.value = netip.Addr{}
+}
+
+// Copy creates a copy of the value
+func (a *AddrValue) Copy() *AddrValue {
+	res := *a
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*AddrValue) Synopsis() string {
+	return "IP"
+}
+
+// Completion obtains the completion for the addresses of the local network interfaces
+func (*AddrValue) Completion() Completion {
+	return interfaceCompletion(func(p netip.Prefix) string {
+		return p.Addr().String()
+	}, false)
+}
+
+func (a *AddrValue) setDirect(v any) error {
+	a.value = v.(netip.Addr)
+	return nil
+}
+
+// Set will parse the address and port
+func (a *AddrPortValue) Set(arg string) error {
+	v, err := netip.ParseAddrPort(strings.TrimSpace(arg))
+	if err != nil {
+		return errors.New("not a valid IP address and port")
+	}
+	a.value = v
+	return nil
+}
+
+// String gets the address and port
+func (a *AddrPortValue) String() string {
+	if !a.value.IsValid() {
+		return ""
+	}
+	return a.value.String()
+}
+
+// Value obtains the address and port
+func (a *AddrPortValue) Value() netip.AddrPort {
+	return a.value
+}
+
+// Reset sets the value back to the zero value
+func (a *AddrPortValue) Reset() {
+	a.value = netip.AddrPort{}
+}
+
+// Copy creates a copy of the value
+func (a *AddrPortValue) Copy() *AddrPortValue {
+	res := *a
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*AddrPortValue) Synopsis() string {
+	return "IP:PORT"
+}
+
+// Completion obtains the completion for the addresses of the local network interfaces.
+// The port must be typed after the address.
+func (*AddrPortValue) Completion() Completion {
+	return interfaceCompletion(func(p netip.Prefix) string {
+		return strings.TrimSuffix(netip.AddrPortFrom(p.Addr(), 0).String(), "0")
+	}, true)
+}
+
+func (a *AddrPortValue) setDirect(v any) error {
+	a.value = v.(netip.AddrPort)
+	return nil
+}
+
+// Set will parse the host and port
+func (h *HostPortValue) Set(arg string) error {
+	arg = strings.TrimSpace(arg)
+	host, port, err := splitHostPort(arg)
+	if err != nil {
+		return err
+	}
+	if port == "" {
+		if h.defaultPort == 0 {
+			return errors.New("missing port in address")
+		}
+		h.host = host
+		h.port = h.defaultPort
+		return nil
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return errors.New("not a valid port: " + port)
+	}
+	h.host = host
+	h.port = int(p)
+	return nil
+}
+
+// String gets the host and port joined with a colon
+func (h *HostPortValue) String() string {
+	if h.host == "" && h.port == 0 {
+		return ""
+	}
+	return net.JoinHostPort(h.host, strconv.Itoa(h.port))
+}
+
+// Value obtains the host and port joined with a colon, which is suitable for
+// net.Dial
+func (h *HostPortValue) Value() string {
+	return h.String()
+}
+
+// Host obtains the host
+func (h *HostPortValue) Host() string {
+	return h.host
+}
+
+// Port obtains the port, which is the default port if it was omitted
+func (h *HostPortValue) Port() int {
+	return h.port
+}
+
+// DefaultPort obtains the default port
+func (h *HostPortValue) DefaultPort() int {
+	return h.defaultPort
+}
+
+// Reset sets the value back to the zero value
+func (h *HostPortValue) Reset() {
+	h.host = ""
+	h.port = 0
+}
+
+// Copy creates a copy of the value
+func (h *HostPortValue) Copy() *HostPortValue {
+	res := *h
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (h *HostPortValue) Synopsis() string {
+	if h.defaultPort == 0 {
+		return "HOST:PORT"
+	}
+	return "HOST[:PORT]"
+}
+
+// Completion obtains the completion for localhost and the addresses of the local network
+// interfaces
+func (h *HostPortValue) Completion() Completion {
+	format := func(host string) string {
+		if h.defaultPort == 0 {
+			return host
+		}
+		return net.JoinHostPort(host, strconv.Itoa(h.defaultPort))
+	}
+	return CompletionFunc(func(c *Context) []CompletionItem {
+		return filterCompletionOnContext(c, func(yield func(CompletionItem) bool) {
+			if !yield(CompletionItem{Value: format("localhost")}) {
+				return
+			}
+			for iface, p := range interfaceAddrs() {
+				item := CompletionItem{Value: format(p.Addr().String()), HelpText: iface.Name}
+				if !yield(item) {
+					return
+				}
+			}
+		})
+	})
+}
+
+func (h *HostPortValue) setDirect(v any) error {
+	return h.Set(v.(string))
+}
+
+// Set will parse the hardware address
+func (a *HardwareAddrValue) Set(arg string) error {
+	v, err := net.ParseMAC(strings.TrimSpace(arg))
+	if err != nil {
+		return errors.New("not a valid hardware address")
+	}
+	a.value = v
+	return nil
+}
+
+// String gets the hardware address
+func (a *HardwareAddrValue) String() string {
+	return a.value.String()
+}
+
+// Value obtains the hardware address
+func (a *HardwareAddrValue) Value() net.HardwareAddr {
+	return a.value
+}
+
+// Reset sets the value back to the zero value
+func (a *HardwareAddrValue) Reset() {
+	a.value = nil
+}
+
+// Copy creates a copy of the value
+func (a *HardwareAddrValue) Copy() *HardwareAddrValue {
+	return &HardwareAddrValue{
+		value: slices.Clone(a.value),
+	}
+}
+
+// Synopsis obtains the synopsis text
+func (*HardwareAddrValue) Synopsis() string {
+	return "MAC"
+}
+
+// Completion obtains the completion for the hardware addresses of the local network interfaces
+func (*HardwareAddrValue) Completion() Completion {
+	return CompletionFunc(func(c *Context) []CompletionItem {
+		return filterCompletionOnContext(c, func(yield func(CompletionItem) bool) {
+			ifaces, _ := net.Interfaces()
+			for _, iface := range ifaces {
+				if len(iface.HardwareAddr) == 0 {
+					continue
+				}
+				item := CompletionItem{Value: iface.HardwareAddr.String(), HelpText: iface.Name}
+				if !yield(item) {
+					return
+				}
+			}
+		})
+	})
+}
+
+func (a *HardwareAddrValue) setDirect(v any) error {
+	a.value = v.(net.HardwareAddr)
+	return nil
+}
+
+// Set will parse the range
+func (r *IPRangeValue) Set(arg string) error {
+	arg = strings.TrimSpace(arg)
+	if from, to, ok := strings.Cut(arg, "-"); ok {
+		f, err1 := netip.ParseAddr(strings.TrimSpace(from))
+		t, err2 := netip.ParseAddr(strings.TrimSpace(to))
+		if err := errors.Join(err1, err2); err != nil {
+			return errors.New("not a valid IP range")
+		}
+		if f.BitLen() != t.BitLen() || t.Less(f) {
+			return errors.New("not a valid IP range")
+		}
+		r.From, r.To = f, t
+		return nil
+	}
+
+	if strings.Contains(arg, "/") {
+		p, err := netip.ParsePrefix(arg)
+		if err != nil {
+			return errors.New("not a valid IP range")
+		}
+		r.From, r.To = prefixRange(p)
+		return nil
+	}
+
+	a, err := netip.ParseAddr(arg)
+	if err != nil {
+		return errors.New("not a valid IP range")
+	}
+	r.From, r.To = a, a
+	return nil
+}
+
+// String gets the range, which is formatted using a hyphen
+func (r *IPRangeValue) String() string {
+	if !r.From.IsValid() {
+		return ""
+	}
+	if r.From == r.To {
+		return r.From.String()
+	}
+	return r.From.String() + "-" + r.To.String()
+}
+
+// Contains determines whether the address is within the range
+func (r *IPRangeValue) Contains(addr netip.Addr) bool {
+	return r.From.IsValid() && r.From.Compare(addr) <= 0 && addr.Compare(r.To) <= 0
+}
+
+// Reset sets the value back to the zero value
+func (r *IPRangeValue) Reset() {
+	*r = IPRangeValue{}
+}
+
+// Copy creates a copy of the value
+func (r *IPRangeValue) Copy() *IPRangeValue {
+	res := *r
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*IPRangeValue) Synopsis() string {
+	return "RANGE"
+}
+
+// Completion obtains the completion for the ranges of the networks of the local network interfaces
+func (*IPRangeValue) Completion() Completion {
+	return interfaceCompletion(func(p netip.Prefix) string {
+		from, to := prefixRange(p)
+		return (&IPRangeValue{From: from, To: to}).String()
+	}, false)
+}
+
+func (r *IPRangeValue) setDirect(v any) error {
+	*r = v.(IPRangeValue)
+	return nil
+}
+
+func splitHostPort(s string) (host, port string, err error) {
+	host, port, err = net.SplitHostPort(s)
+	if err == nil {
+		if host == "" {
+			return "", "", errors.New("missing host in address")
+		}
+		return
+	}
+
+	// An IPv6 address can be used without a port and with or without brackets
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" || strings.ContainsAny(s, "[]") {
+		return "", "", errors.New("not a valid host and port")
+	}
+	if strings.Contains(s, ":") {
+		if _, err := netip.ParseAddr(s); err != nil {
+			return "", "", errors.New("not a valid host and port")
+		}
+	}
+	return s, "", nil
+}
+
+func prefixRange(p netip.Prefix) (from, to netip.Addr) {
+	p = p.Masked()
+	from = p.Addr()
+	last := from.AsSlice()
+	for i := p.Bits(); i < len(last)*8; i++ {
+		last[i/8] |= 1 << (7 - i%8)
+	}
+	to, _ = netip.AddrFromSlice(last)
+	return from, to.WithZone(from.Zone())
+}
+
+func interfaceAddrs() iter.Seq2[net.Interface, netip.Prefix] {
+	return func(yield func(net.Interface, netip.Prefix) bool) {
+		ifaces, _ := net.Interfaces()
+		for _, iface := range ifaces {
+			addrs, _ := iface.Addrs()
+			for _, a := range addrs {
+				n, ok := a.(*net.IPNet)
+				if !ok {
+					continue
+				}
+				addr, ok := netip.AddrFromSlice(n.IP)
+				if !ok {
+					continue
+				}
+				bits, _ := n.Mask.Size()
+				if !yield(iface, netip.PrefixFrom(addr.Unmap(), bits)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func interfaceCompletion(format func(netip.Prefix) string, preventSpaceAfter bool) Completion {
+	return CompletionFunc(func(c *Context) []CompletionItem {
+		return filterCompletionOnContext(c, func(yield func(CompletionItem) bool) {
+			seen := map[string]bool{}
+			for iface, p := range interfaceAddrs() {
+				value := format(p)
+				if seen[value] {
+					continue
+				}
+				seen[value] = true
+				item := CompletionItem{
+					Value:             value,
+					HelpText:          iface.Name,
+					PreventSpaceAfter: preventSpaceAfter,
+				}
+				if !yield(item) {
+					return
+				}
+			}
+		})
+	})
+}
+
+var (
+	_ Value = (*IPNetValue)(nil)
+	_ Value = (*AddrValue)(nil)
+	_ Value = (*AddrPortValue)(nil)
+	_ Value = (*HostPortValue)(nil)
+	_ Value = (*HardwareAddrValue)(nil)
+	_ Value = (*IPRangeValue)(nil)
+)
@@ -0,0 +1,145 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/bind"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("network values", func() {
+
+	DescribeTable("examples",
+		func(v cli.Value, text string, expected types.GomegaMatcher) {
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.String()).To(expected)
+		},
+		Entry("IPNet", cli.IPNet(), "10.0.0.0/8", Equal("10.0.0.0/8")),
+		Entry("IPNet IPv6", cli.IPNet(), "fd00::/64", Equal("fd00::/64")),
+		Entry("Addr", cli.Addr(), "10.0.0.1", Equal("10.0.0.1")),
+		Entry("Addr IPv6", cli.Addr(), "::1", Equal("::1")),
+		Entry("AddrPort", cli.AddrPort(), "10.0.0.1:80", Equal("10.0.0.1:80")),
+		Entry("AddrPort IPv6", cli.AddrPort(), "[::1]:80", Equal("[::1]:80")),
+		Entry("HostPort", cli.HostPort(0), "example.com:8080", Equal("example.com:8080")),
+		Entry("HostPort default port", cli.HostPort(443), "example.com", Equal("example.com:443")),
+		Entry("HostPort IPv6 default port", cli.HostPort(443), "::1", Equal("[::1]:443")),
+		Entry("HostPort IPv6 brackets default port", cli.HostPort(443), "[::1]", Equal("[::1]:443")),
+		Entry("HardwareAddr", cli.HardwareAddr(), "00-00-5E-00-53-01", Equal("00:00:5e:00:53:01")),
+		Entry("IPRange", cli.IPRange(), "10.0.0.1-10.0.0.20", Equal("10.0.0.1-10.0.0.20")),
+		Entry("IPRange CIDR", cli.IPRange(), "10.0.0.0/30", Equal("10.0.0.0-10.0.0.3")),
+		Entry("IPRange single", cli.IPRange(), "10.0.0.1", Equal("10.0.0.1")),
+	)
+
+	DescribeTable("errors",
+		func(v cli.Value, text string, expected string) {
+			Expect(v.Set(text)).To(MatchError(expected))
+		},
+		Entry("IPNet", cli.IPNet(), "10.0.0.0", "not a valid CIDR network"),
+		Entry("Addr", cli.Addr(), "10.0.0.256", "not a valid IP address"),
+		Entry("AddrPort", cli.AddrPort(), "10.0.0.1", "not a valid IP address and port"),
+		Entry("HostPort missing port", cli.HostPort(0), "example.com", "missing port in address"),
+		Entry("HostPort invalid port", cli.HostPort(0), "example.com:http", "not a valid port: http"),
+		Entry("HostPort missing host", cli.HostPort(0), ":80", "missing host in address"),
+		Entry("HardwareAddr", cli.HardwareAddr(), "00:00", "not a valid hardware address"),
+		Entry("IPRange reversed", cli.IPRange(), "10.0.0.20-10.0.0.1", "not a valid IP range"),
+		Entry("IPRange mixed families", cli.IPRange(), "10.0.0.1-::1", "not a valid IP range"),
+	)
+
+	DescribeTable("synopsis",
+		func(v cli.Value, expected string) {
+			f := &cli.Flag{Name: "addr", Value: v}
+			Expect(f.Synopsis()).To(Equal(expected))
+		},
+		Entry("IPNet", cli.IPNet(), "--addr=CIDR"),
+		Entry("Addr", cli.Addr(), "--addr=IP"),
+		Entry("AddrPort", cli.AddrPort(), "--addr=IP:PORT"),
+		Entry("HostPort", cli.HostPort(0), "--addr=HOST:PORT"),
+		Entry("HostPort default port", cli.HostPort(80), "--addr=HOST[:PORT]"),
+		Entry("HardwareAddr", cli.HardwareAddr(), "--addr=MAC"),
+		Entry("IPRange", cli.IPRange(), "--addr=RANGE"),
+	)
+
+	It("obtains values from the context", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "net", Value: cli.IPNet()},
+				{Name: "addr", Value: cli.Addr()},
+				{Name: "listen", Value: cli.AddrPort()},
+				{Name: "server", Value: cli.HostPort(443)},
+				{Name: "mac", Value: cli.HardwareAddr()},
+				{Name: "range", Value: cli.IPRange()},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app --net 10.0.0.0/8 --addr 10.0.0.1 --listen 0.0.0.0:80 --server example.com --mac 00:00:5e:00:53:01 --range 10.0.0.1-10.0.0.20")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.IPNet("net")).To(Equal(netip.MustParsePrefix("10.0.0.0/8")))
+		Expect(captured.Addr("addr")).To(Equal(netip.MustParseAddr("10.0.0.1")))
+		Expect(captured.AddrPort("listen")).To(Equal(netip.MustParseAddrPort("0.0.0.0:80")))
+		Expect(captured.HostPort("server")).To(Equal("example.com:443"))
+		Expect(captured.HardwareAddr("mac")).To(Equal(net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01}))
+		Expect(captured.IPRange("range").Contains(netip.MustParseAddr("10.0.0.7"))).To(BeTrue())
+		Expect(captured.IPRange("range").Contains(netip.MustParseAddr("10.0.0.21"))).To(BeFalse())
+	})
+
+	It("can be bound", func() {
+		var (
+			prefix netip.Prefix
+			server string
+			ips    *cli.IPRangeValue
+		)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{
+					Name: "net",
+					Uses: bind.Call(func(p netip.Prefix) error {
+						prefix = p
+						return nil
+					}, bind.IPNet()),
+				},
+				{
+					Name: "server",
+					Uses: bind.Call(func(s string) error {
+						server = s
+						return nil
+					}, bind.HostPort(8080)),
+				},
+				{
+					Name: "range",
+					Uses: bind.Call(func(r *cli.IPRangeValue) error {
+						ips = r
+						return nil
+					}, bind.IPRange()),
+				},
+			},
+		}
+
+		args, _ := cli.Split("app --net 192.168.0.0/16 --server localhost --range 10.0.0.0/30")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(prefix).To(Equal(netip.MustParsePrefix("192.168.0.0/16")))
+		Expect(server).To(Equal("localhost:8080"))
+		Expect(ips.String()).To(Equal("10.0.0.0-10.0.0.3"))
+	})
+
+	It("converts IPNet to net.IPNet", func() {
+		v := cli.IPNet()
+		_ = v.Set("10.1.2.3/8")
+		Expect(v.IPNet().String()).To(Equal("10.0.0.0/8"))
+	})
+})
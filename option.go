@@ -8,7 +8,9 @@ import (
 	"context"
 	"encoding"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"slices"
 	"strings"
 )
@@ -26,7 +28,7 @@ type Feature interface {
 // joe-cli is to define a bitmask representing features that apply in an extension
 type FeatureMap[T Feature] map[T]Action
 
-type internalFlags uint32
+type internalFlags uint64
 
 const (
 	// Hidden causes the option to be Hidden
@@ -275,6 +277,16 @@ const (
 	// option cannot be present on any target.
 	ReservedOption4
 
+	// ResponseFiles causes arguments of the form @path to be replaced with the contents
+	// of the named file before the command line is parsed.  The contents of the file are
+	// split using shell splitting rules (see Split), and any arguments of the same form within
+	// the file are expanded recursively.  It is an error for a file to include itself either
+	// directly or indirectly.  Arguments which follow the "--" delimiter are not expanded.
+	// Files are read from the FS of the app.  This option is typically set on the App so
+	// that long command lines (such as those generated by build systems) can be passed
+	// in a file.
+	ResponseFiles
+
 	maxOption
 
 	reservedOptionMask = ReservedOption1 | ReservedOption2 | ReservedOption3 | ReservedOption4
@@ -319,6 +331,8 @@ const (
 	internalFlagDisableSuggestions
 	internalFlagOrderFirst
 	internalFlagOrderLast
+	internalFlagResponseFiles
+	internalFlagExecutesSubcommand // true for the arg which executes the sub-command
 )
 
 var (
@@ -352,6 +366,7 @@ var (
 		DisableSuggestions:      setInternalFlag(internalFlagDisableSuggestions),
 		OrderFirst:              setInternalFlag(internalFlagOrderFirst),
 		OrderLast:               setInternalFlag(internalFlagOrderLast),
+		ResponseFiles:           setInternalFlag(internalFlagResponseFiles),
 		ReservedOption1:         ActionFunc(nil), // Reserved options are enforced in the default pipelines
 		ReservedOption2:         ActionFunc(nil),
 		ReservedOption3:         ActionFunc(nil),
@@ -390,6 +405,7 @@ var (
 		DisableSuggestions:      "DISABLE_SUGGESTIONS",
 		OrderFirst:              "ORDER_FIRST",
 		OrderLast:               "ORDER_LAST",
+		ResponseFiles:           "RESPONSE_FILES",
 		ReservedOption1:         "RESERVED_OPTION_1",
 		ReservedOption2:         "RESERVED_OPTION_2",
 		ReservedOption3:         "RESERVED_OPTION_3",
@@ -481,6 +497,10 @@ func (f internalFlags) didSubcommandExecute() bool {
 	return f&internalFlagDidSubcommandExecute == internalFlagDidSubcommandExecute
 }
 
+func (f internalFlags) executesSubcommand() bool {
+	return f&internalFlagExecutesSubcommand == internalFlagExecutesSubcommand
+}
+
 func (f internalFlags) disableSplitting() bool {
 	return f&internalFlagDisableSplitting == internalFlagDisableSplitting
 }
@@ -581,6 +601,10 @@ func (f internalFlags) orderLast() bool {
 	return f&internalFlagOrderLast == internalFlagOrderLast
 }
 
+func (f internalFlags) responseFiles() bool {
+	return f&internalFlagResponseFiles == internalFlagResponseFiles
+}
+
 // orderClass produces a sort key to break ties when DependsOn is used
 func (f internalFlags) orderClass() int {
 	switch {
@@ -790,6 +814,57 @@ func fileReferenceOpt(c context.Context) error {
 	return Do(c, Transform(TransformFileReference(FromContext(c).actualFS())))
 }
 
+// expandResponseFiles replaces each argument of the form @path with the
+// shell-split contents of the file, recursively.  The program name and arguments
+// after "--" are never expanded.
+func expandResponseFiles(fsys fs.FS, args []string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+	res := []string{args[0]}
+	var (
+		stack      []string
+		terminated bool
+		expand     func([]string) error
+	)
+
+	expand = func(args []string) error {
+		for _, arg := range args {
+			if terminated || len(arg) < 2 || arg[0] != '@' {
+				terminated = terminated || arg == "--"
+				res = append(res, arg)
+				continue
+			}
+
+			name := path.Clean(arg[1:])
+			if slices.Contains(stack, name) {
+				return fmt.Errorf("response file cycle detected: %s", strings.Join(append(stack, name), " -> "))
+			}
+
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return fmt.Errorf("response file: %w", err)
+			}
+			contents, err := Split(string(data))
+			if err != nil {
+				return fmt.Errorf("response file %s: %w", name, err)
+			}
+
+			stack = append(stack, name)
+			if err := expand(contents); err != nil {
+				return err
+			}
+			stack = stack[0 : len(stack)-1]
+		}
+		return nil
+	}
+
+	if err := expand(args[1:]); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func sortedFlagsOpt(c *Context) error {
 	cmd := c.Command()
 	slices.SortFunc(cmd.Flags, flagsByNameOrder)
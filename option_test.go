@@ -7,12 +7,15 @@ package cli_test
 import (
 	"context"
 	"encoding/json"
+	"io/fs"
 	"strconv"
+	"testing/fstest"
 
 	"github.com/Carbonfrost/joe-cli"
 	"github.com/Carbonfrost/joe-cli/joe-clifakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("Option", func() {
@@ -53,6 +56,7 @@ var _ = Describe("Option", func() {
 			Entry("DisableSynopsisCategories", cli.DisableSynopsisCategories, "DISABLE_SYNOPSIS_CATEGORIES"),
 			Entry("OrderFirst", cli.OrderFirst, "ORDER_FIRST"),
 			Entry("OrderLast", cli.OrderLast, "ORDER_LAST"),
+			Entry("ResponseFiles", cli.ResponseFiles, "RESPONSE_FILES"),
 			Entry("ReservedOption1", cli.ReservedOption1, "RESERVED_OPTION_1"),
 			Entry("ReservedOption2", cli.ReservedOption2, "RESERVED_OPTION_2"),
 			Entry("ReservedOption3", cli.ReservedOption3, "RESERVED_OPTION_3"),
@@ -72,6 +76,83 @@ var _ = Describe("Option", func() {
 		})
 	})
 
+	Describe("ResponseFiles", func() {
+
+		var testFileSystem = fstest.MapFS{
+			"args.rsp":   {Data: []byte("-f 'a b' @nested.rsp")},
+			"nested.rsp": {Data: []byte("--g c\n")},
+			"cycle.rsp":  {Data: []byte("@other.rsp")},
+			"other.rsp":  {Data: []byte("-f x @cycle.rsp")},
+		}
+
+		DescribeTable("examples", func(arguments string, expected types.GomegaMatcher) {
+			var actual []string
+			app := &cli.App{
+				Name:    "app",
+				FS:      testFileSystem,
+				Options: cli.ResponseFiles,
+				Flags: []*cli.Flag{
+					{Name: "f"},
+					{Name: "g"},
+				},
+				Args: cli.Args("rest", cli.List()),
+				Action: func(c *cli.Context) {
+					actual = append(c.Raw("f"), c.Raw("g")...)
+					actual = append(actual, c.List("rest")...)
+				},
+			}
+
+			args, _ := cli.Split(arguments)
+			err := app.RunContext(context.Background(), args)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(expected)
+		},
+			Entry("expands file", "app @args.rsp", Equal([]string{"-f", "a b", "-g", "c"})),
+			Entry("literal @", "app -f @ x", Equal([]string{"-f", "@", "x"})),
+			Entry("after delimiter", "app -- @args.rsp", Equal([]string{"@args.rsp"})),
+		)
+
+		It("detects cycles", func() {
+			app := &cli.App{
+				Name:    "app",
+				FS:      testFileSystem,
+				Options: cli.ResponseFiles,
+				Flags: []*cli.Flag{
+					{Name: "f"},
+				},
+			}
+
+			err := app.RunContext(context.Background(), []string{"app", "@cycle.rsp"})
+			Expect(err).To(MatchError("response file cycle detected: cycle.rsp -> other.rsp -> cycle.rsp"))
+		})
+
+		It("returns error when file is missing", func() {
+			app := &cli.App{
+				Name:    "app",
+				FS:      testFileSystem,
+				Options: cli.ResponseFiles,
+			}
+
+			err := app.RunContext(context.Background(), []string{"app", "@missing.rsp"})
+			Expect(err).To(MatchError(fs.ErrNotExist))
+		})
+
+		It("does not expand when option is not set", func() {
+			var actual []string
+			app := &cli.App{
+				Name: "app",
+				FS:   testFileSystem,
+				Args: cli.Args("rest", cli.List()),
+				Action: func(c *cli.Context) {
+					actual = c.List("rest")
+				},
+			}
+
+			_ = app.RunContext(context.Background(), []string{"app", "@args.rsp"})
+			Expect(actual).To(Equal([]string{"@args.rsp"}))
+		})
+	})
+
 	var _ = Describe("reserved options", func() {
 
 		DescribeTableSubtree("errors", func(o cli.Option) {
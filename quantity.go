@@ -0,0 +1,453 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Carbonfrost/joe-cli/internal/support"
+)
+
+// ByteSizeValue provides a value which parses a number of bytes.  The number
+// can use a decimal (kB, MB, GB, ...) or binary (KiB, MiB, GiB, ...) unit.  The B
+// is optional, and the prefix is case-insensitive, so 1.5k, 1.5kB, and 1.5KB all
+// denote 1500 bytes whereas 1.5Ki and 1.5KiB denote 1536 bytes.  A lowercase b
+// denotes bits, so 16kb denotes 2000 bytes.  The size is formatted using the
+// largest unit which represents it exactly.  The syntax is the same as
+// value.ByteLength.
+type ByteSizeValue struct {
+	quantity[int64, byteSizeUnits]
+}
+
+// PercentValue provides a value which parses a percentage such as 50% or 12.5%.
+// The value is the corresponding fraction, so 50% is 0.5.  A number without the
+// percent sign is also interpreted as a fraction.
+type PercentValue struct {
+	quantity[float64, percentUnits]
+}
+
+// SIValue provides a value which parses a number with an optional SI prefix
+// such as 1.5k (1500), 2M, or 250m (0.25).  The prefixes k, M, G, T, P, and E
+// and m, u (or µ), and n are supported.
+type SIValue struct {
+	quantity[float64, siUnits]
+}
+
+// Rate provides a value which is a quantity per interval of time, such as 10/s,
+// 100/min, 1.5k/h, or 5MB/s.  The interval can be one of the units s, min, h, or d
+// or a duration such as 100ms.  When the quantity has a byte unit, the rate is
+// a number of bytes and is formatted using byte units.  As with ByteSizeValue, a
+// lowercase b denotes bits, so 8Mb/s is 1MB/s.
+type Rate struct {
+	// Quantity is the amount which occurs during each interval
+	Quantity float64
+	// Interval is the interval of time
+	Interval time.Duration
+	// Bytes indicates that the quantity is a number of bytes
+	Bytes bool
+}
+
+// quantity provides the value of a quantity.  The units, which parse and format
+// the quantity, are determined by the type U so that the zero value can be used.
+type quantity[T int64 | float64, U quantityUnits[T]] struct {
+	value T
+	min   *T
+	max   *T
+}
+
+type quantityUnits[T int64 | float64] interface {
+	parse(string) (T, error)
+	format(T) string
+	synopsis() string
+}
+
+type byteSizeUnits struct{}
+type percentUnits struct{}
+type siUnits struct{}
+
+var (
+	siPattern = regexp.MustCompile(`^([-+]?[0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*([kMGTPEmunµ]?)$`)
+
+	siPrefixes = []struct {
+		prefix string
+		scale  float64
+	}{
+		{"E", 1e18},
+		{"P", 1e15},
+		{"T", 1e12},
+		{"G", 1e9},
+		{"M", 1e6},
+		{"k", 1e3},
+		{"", 1},
+		{"m", 1e-3},
+		{"u", 1e-6},
+		{"n", 1e-9},
+	}
+	rateUnits = map[string]time.Duration{
+		"s":      time.Second,
+		"sec":    time.Second,
+		"second": time.Second,
+		"m":      time.Minute,
+		"min":    time.Minute,
+		"minute": time.Minute,
+		"h":      time.Hour,
+		"hr":     time.Hour,
+		"hour":   time.Hour,
+		"d":      24 * time.Hour,
+		"day":    24 * time.Hour,
+	}
+)
+
+// ByteSize creates a byte size value
+func ByteSize() *ByteSizeValue {
+	return new(ByteSizeValue)
+}
+
+// Percent creates a percentage value
+func Percent() *PercentValue {
+	return new(PercentValue)
+}
+
+// SI creates a number value which allows SI prefixes
+func SI() *SIValue {
+	return new(SIValue)
+}
+
+// Min sets the minimum number of bytes, which is checked when the value is set
+func (b *ByteSizeValue) Min(v int64) *ByteSizeValue {
+	b.min = &v
+	return b
+}
+
+// Max sets the maximum number of bytes, which is checked when the value is set
+func (b *ByteSizeValue) Max(v int64) *ByteSizeValue {
+	b.max = &v
+	return b
+}
+
+// Copy creates a copy of the value
+func (b *ByteSizeValue) Copy() *ByteSizeValue {
+	return &ByteSizeValue{b.quantity}
+}
+
+// Min sets the minimum fraction, which is checked when the value is set
+func (p *PercentValue) Min(v float64) *PercentValue {
+	p.min = &v
+	return p
+}
+
+// Max sets the maximum fraction, which is checked when the value is set
+func (p *PercentValue) Max(v float64) *PercentValue {
+	p.max = &v
+	return p
+}
+
+// Copy creates a copy of the value
+func (p *PercentValue) Copy() *PercentValue {
+	return &PercentValue{p.quantity}
+}
+
+// Min sets the minimum number, which is checked when the value is set
+func (s *SIValue) Min(v float64) *SIValue {
+	s.min = &v
+	return s
+}
+
+// Max sets the maximum number, which is checked when the value is set
+func (s *SIValue) Max(v float64) *SIValue {
+	s.max = &v
+	return s
+}
+
+// Copy creates a copy of the value
+func (s *SIValue) Copy() *SIValue {
+	return &SIValue{s.quantity}
+}
+
+// Set will parse the quantity and check that it is within the bounds
+func (q *quantity[T, U]) Set(arg string) error {
+	var units U
+	v, err := units.parse(strings.TrimSpace(arg))
+	if err != nil {
+		return err
+	}
+	if err := q.check(v); err != nil {
+		return err
+	}
+	q.value = v
+	return nil
+}
+
+// String formats the quantity using its units
+func (q *quantity[T, U]) String() string {
+	return q.format(q.value)
+}
+
+func (q *quantity[T, U]) format(v T) string {
+	var units U
+	return units.format(v)
+}
+
+// Value obtains the quantity
+func (q *quantity[T, U]) Value() T {
+	return q.value
+}
+
+// Reset sets the value back to zero
+func (q *quantity[T, U]) Reset() {
+	q.value = 0
+}
+
+// Synopsis obtains the synopsis text
+func (q *quantity[T, U]) Synopsis() string {
+	var units U
+	return units.synopsis()
+}
+
+// Initializer obtains the initializer for the value, which sets the default
+// text to the formatted quantity when it is not zero
+func (q *quantity[T, U]) Initializer() Action {
+	return ActionFunc(func(c *Context) error {
+		if q.value == 0 {
+			return nil
+		}
+		return c.Do(&Prototype{DefaultText: q.String()})
+	})
+}
+
+func (q *quantity[T, U]) check(v T) error {
+	if q.min != nil && v < *q.min {
+		return fmt.Errorf("must be at least %s", q.format(*q.min))
+	}
+	if q.max != nil && v > *q.max {
+		return fmt.Errorf("must be at most %s", q.format(*q.max))
+	}
+	return nil
+}
+
+func (q *quantity[T, U]) setDirect(v any) error {
+	val := v.(T)
+	if err := q.check(val); err != nil {
+		return err
+	}
+	q.value = val
+	return nil
+}
+
+// Set will parse the rate
+func (r *Rate) Set(arg string) error {
+	amount, per, ok := strings.Cut(strings.TrimSpace(arg), "/")
+	if !ok {
+		return fmt.Errorf("not a valid rate: %s", arg)
+	}
+
+	interval, err := parseRateInterval(strings.TrimSpace(per))
+	if err != nil {
+		return err
+	}
+
+	amount = strings.TrimSpace(amount)
+	var (
+		q     float64
+		bytes = strings.HasSuffix(amount, "B") || strings.HasSuffix(amount, "b")
+	)
+	if bytes {
+		var n int64
+		n, err = byteSizeUnits{}.parse(amount)
+		q = float64(n)
+	} else {
+		q, err = siUnits{}.parse(amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.Quantity = q
+	r.Interval = interval
+	r.Bytes = bytes
+	return nil
+}
+
+// String formats the rate using its units
+func (r *Rate) String() string {
+	if r.Interval == 0 {
+		return ""
+	}
+	var amount string
+	if r.Bytes {
+		amount = support.FormatByteSize(int64(r.Quantity))
+	} else {
+		amount = siUnits{}.format(r.Quantity)
+	}
+	return amount + "/" + formatRateInterval(r.Interval)
+}
+
+// PerSecond obtains the quantity which occurs each second
+func (r *Rate) PerSecond() float64 {
+	return r.Per(time.Second)
+}
+
+// Per obtains the quantity which occurs during the given interval
+func (r *Rate) Per(d time.Duration) float64 {
+	if r.Interval == 0 {
+		return 0
+	}
+	return r.Quantity * float64(d) / float64(r.Interval)
+}
+
+// Reset sets the value back to the zero value
+func (r *Rate) Reset() {
+	*r = Rate{}
+}
+
+// Copy creates a copy of the value
+func (r *Rate) Copy() *Rate {
+	res := *r
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*Rate) Synopsis() string {
+	return "RATE"
+}
+
+// Initializer obtains the initializer for the value, which sets the default
+// text to the formatted rate when it is set
+func (r *Rate) Initializer() Action {
+	return ActionFunc(func(c *Context) error {
+		if r.Interval == 0 {
+			return nil
+		}
+		return c.Do(&Prototype{DefaultText: r.String()})
+	})
+}
+
+func (r *Rate) setDirect(v any) error {
+	*r = v.(Rate)
+	return nil
+}
+
+func (byteSizeUnits) parse(s string) (int64, error) {
+	res, err := support.ParseByteSize(s)
+	if err != nil {
+		return 0, fmt.Errorf("not a valid byte size: %s", s)
+	}
+	if res >= math.MaxInt64 {
+		return 0, fmt.Errorf("value out of range: %s", s)
+	}
+	return int64(res), nil
+}
+
+func (byteSizeUnits) format(v int64) string {
+	return support.FormatByteSize(v)
+}
+
+func (byteSizeUnits) synopsis() string {
+	return "SIZE"
+}
+
+func (percentUnits) parse(s string) (float64, error) {
+	text, percent := strings.CutSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, formatStrconvError(err, s)
+	}
+	if percent {
+		v /= 100
+	}
+	return v, nil
+}
+
+func (percentUnits) format(v float64) string {
+	return formatFloat(v*100) + "%"
+}
+
+func (siUnits) parse(s string) (float64, error) {
+	m := siPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("not a valid number: %s", s)
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, formatStrconvError(err, s)
+	}
+	prefix := m[2]
+	if prefix == "µ" {
+		prefix = "u"
+	}
+	for _, p := range siPrefixes {
+		if p.prefix == prefix {
+			return v * p.scale, nil
+		}
+	}
+	return v, nil
+}
+
+func (siUnits) format(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	abs := math.Abs(v)
+	for _, p := range siPrefixes {
+		if abs >= p.scale {
+			return formatFloat(v/p.scale) + p.prefix
+		}
+	}
+	last := siPrefixes[len(siPrefixes)-1]
+	return formatFloat(v/last.scale) + last.prefix
+}
+
+func (percentUnits) synopsis() string {
+	return "PERCENT"
+}
+
+func (siUnits) synopsis() string {
+	return "NUMBER"
+}
+
+func parseRateInterval(s string) (time.Duration, error) {
+	if d, ok := rateUnits[strings.ToLower(s)]; ok {
+		return d, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errors.New("not a valid rate interval: " + s)
+	}
+	return d, nil
+}
+
+func formatRateInterval(d time.Duration) string {
+	switch d {
+	case time.Second:
+		return "s"
+	case time.Minute:
+		return "min"
+	case time.Hour:
+		return "h"
+	case 24 * time.Hour:
+		return "d"
+	}
+	return d.String()
+}
+
+// formatFloat formats the number with the shortest representation after rounding
+// away imprecision from floating point arithmetic
+func formatFloat(v float64) string {
+	v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+var (
+	_ Value = (*ByteSizeValue)(nil)
+	_ Value = (*PercentValue)(nil)
+	_ Value = (*SIValue)(nil)
+	_ Value = (*Rate)(nil)
+)
@@ -0,0 +1,191 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/bind"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("quantity values", func() {
+
+	DescribeTable("ByteSize examples",
+		func(text string, expected int64, expectedText string) {
+			v := cli.ByteSize()
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.Value()).To(Equal(expected))
+			Expect(v.String()).To(Equal(expectedText))
+		},
+		Entry("bytes", "512", int64(512), "512B"),
+		Entry("bytes with unit", "512B", int64(512), "512B"),
+		Entry("decimal", "1.5kB", int64(1500), "1.5kB"),
+		Entry("decimal without B", "2M", int64(2000000), "2MB"),
+		Entry("decimal case-insensitive", "2mB", int64(2000000), "2MB"),
+		Entry("bits", "16kb", int64(2000), "2kB"),
+		Entry("binary", "1.5KiB", int64(1536), "1.5KiB"),
+		Entry("binary without B", "4Gi", int64(4<<30), "4GiB"),
+		Entry("not exact", "1001", int64(1001), "1001B"),
+		Entry("zero", "0", int64(0), "0B"),
+	)
+
+	DescribeTable("Percent examples",
+		func(text string, expected float64, expectedText string) {
+			v := cli.Percent()
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.Value()).To(BeNumerically("~", expected, 1e-9))
+			Expect(v.String()).To(Equal(expectedText))
+		},
+		Entry("percent", "50%", 0.5, "50%"),
+		Entry("fractional percent", "12.5%", 0.125, "12.5%"),
+		Entry("fraction", "0.07", 0.07, "7%"),
+	)
+
+	DescribeTable("SI examples",
+		func(text string, expected float64, expectedText string) {
+			v := cli.SI()
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.Value()).To(BeNumerically("~", expected, 1e-9))
+			Expect(v.String()).To(Equal(expectedText))
+		},
+		Entry("plain", "42", 42.0, "42"),
+		Entry("kilo", "1.5k", 1500.0, "1.5k"),
+		Entry("mega", "2M", 2e6, "2M"),
+		Entry("milli", "250m", 0.25, "250m"),
+		Entry("micro", "3µ", 3e-6, "3u"),
+		Entry("negative", "-4k", -4000.0, "-4k"),
+	)
+
+	DescribeTable("Rate examples",
+		func(text string, perSecond float64, expectedText string) {
+			v := new(cli.Rate)
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.PerSecond()).To(BeNumerically("~", perSecond, 1e-9))
+			Expect(v.String()).To(Equal(expectedText))
+		},
+		Entry("per second", "10/s", 10.0, "10/s"),
+		Entry("per minute", "120/min", 2.0, "120/min"),
+		Entry("SI prefix", "1.5k/h", 1500.0/3600, "1.5k/h"),
+		Entry("bytes", "5MB/s", 5e6, "5MB/s"),
+		Entry("bits", "8Mb/s", 1e6, "1MB/s"),
+		Entry("duration", "1/100ms", 10.0, "1/100ms"),
+	)
+
+	DescribeTable("errors",
+		func(v cli.Value, text string, expected string) {
+			Expect(v.Set(text)).To(MatchError(expected))
+		},
+		Entry("ByteSize", cli.ByteSize(), "lots", "not a valid byte size: lots"),
+		Entry("ByteSize fractional bytes", cli.ByteSize(), "1.5", "not a valid byte size: 1.5"),
+		Entry("Percent", cli.Percent(), "x%", "not a valid number: x%"),
+		Entry("SI", cli.SI(), "1.5q", "not a valid number: 1.5q"),
+		Entry("Rate", new(cli.Rate), "10", "not a valid rate: 10"),
+		Entry("Rate interval", new(cli.Rate), "10/fortnight", "not a valid rate interval: fortnight"),
+		Entry("ByteSize minimum", cli.ByteSize().Min(1024), "1000", "must be at least 1KiB"),
+		Entry("ByteSize maximum", cli.ByteSize().Max(1<<20), "2MiB", "must be at most 1MiB"),
+		Entry("Percent maximum", cli.Percent().Max(1), "120%", "must be at most 100%"),
+		Entry("SI minimum", cli.SI().Min(0), "-1", "must be at least 0"),
+	)
+
+	DescribeTable("zero value",
+		func(v cli.Value, text string, expected string) {
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.String()).To(Equal(expected))
+		},
+		Entry("ByteSize", new(cli.ByteSizeValue), "1K", "1kB"),
+		Entry("Percent", new(cli.PercentValue), "50%", "50%"),
+		Entry("SI", new(cli.SIValue), "2M", "2M"),
+	)
+
+	It("displays the default text in human form", func() {
+		size := cli.ByteSize()
+		_ = size.Set("64MiB")
+		rate := new(cli.Rate)
+		_ = rate.Set("100/s")
+
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "cache-size", Value: size, HelpText: "Size of the cache"},
+				{Name: "rate-limit", Value: rate, HelpText: "Maximum requests"},
+			},
+		}
+
+		screen := renderScreen(app, "app --help")
+		Expect(screen).To(ContainSubstring("Size of the cache (default: 64MiB)"))
+		Expect(screen).To(ContainSubstring("Maximum requests (default: 100/s)"))
+	})
+
+	It("returns an error for values out of bounds", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "size", Value: cli.ByteSize().Max(1 << 20)},
+			},
+		}
+
+		args, _ := cli.Split("app --size 2MiB")
+		Expect(app.RunContext(context.Background(), args)).To(MatchError(ContainSubstring("must be at most 1MiB")))
+	})
+
+	It("obtains values from the context", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "size", Value: cli.ByteSize()},
+				{Name: "ratio", Value: cli.Percent()},
+				{Name: "count", Value: cli.SI()},
+				{Name: "rate", Value: new(cli.Rate)},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app --size 1KiB --ratio 25% --count 3k --rate 5MB/s")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.ByteSize("size")).To(Equal(int64(1024)))
+		Expect(captured.Percent("ratio")).To(Equal(0.25))
+		Expect(captured.SI("count")).To(Equal(3000.0))
+		Expect(captured.Rate("rate").Per(time.Minute)).To(Equal(300e6))
+	})
+
+	It("can be bound", func() {
+		var actual int64
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{
+					Name: "size",
+					Uses: bind.Call(func(n int64) error {
+						actual = n
+						return nil
+					}, bind.ByteSize()),
+				},
+			},
+		}
+
+		args, _ := cli.Split("app --size 2KiB")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(actual).To(Equal(int64(2048)))
+	})
+
+	DescribeTable("synopsis",
+		func(v cli.Value, expected string) {
+			f := &cli.Flag{Name: "value", Value: v}
+			Expect(f.Synopsis()).To(Equal(expected))
+		},
+		Entry("ByteSize", cli.ByteSize(), "--value=SIZE"),
+		Entry("Percent", cli.Percent(), "--value=PERCENT"),
+		Entry("SI", cli.SI(), "--value=NUMBER"),
+		Entry("Rate", new(cli.Rate), "--value=RATE"),
+	)
+})
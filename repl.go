@@ -0,0 +1,327 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// maxHistory is the number of lines kept in the history of the interactive shell
+const maxHistory = 1000
+
+type repl struct {
+	ctx    *Context
+	prompt string
+	out    io.Writer
+
+	// restore restores the values of the flags and args of sub-commands to those
+	// from before the first line was executed
+	restore []func()
+}
+
+type historyFile struct {
+	path  string
+	lines []string
+}
+
+// InteractiveShell provides an action which turns the command into an interactive
+// shell (REPL).  Each line read from Stdin is split using Split and executed as a
+// sub-command of the command using the same initialized command tree.  The values
+// of persistent flags, which are the flags of the command, are preserved between
+// lines so that state such as the connection to a server is kept.  The values of
+// the flags and args of sub-commands are restored before each line to those they
+// had when the shell started.  Errors are printed to Stderr and do not stop the
+// shell.  The shell stops at the end of input or when the line "exit" or "quit"
+// is read, unless a sub-command has that name.
+//
+// When Stdin is a terminal, line editing is provided.  Pressing Tab completes
+// the current word using the completion of the command tree, and history is kept
+// in the file named history within the directory named for the app in the user cache
+// directory (see os.UserCacheDir).
+//
+// Typically, InteractiveShell is used as the Action of the App or of a dedicated
+// command named "shell".
+func InteractiveShell() Action {
+	return ActionFunc(func(c *Context) error {
+		r := newREPL(c)
+		if f, ok := c.Stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			return r.runTerminal(f)
+		}
+		return r.run(c.Stdin)
+	})
+}
+
+func newREPL(c *Context) *repl {
+	r := &repl{
+		ctx:    c,
+		prompt: c.App().Name + "> ",
+		out:    c.Stdout,
+	}
+	var snapshotCommand func(*Command)
+	snapshotCommand = func(cmd *Command) {
+		for _, sub := range cmd.Subcommands {
+			for _, f := range sub.Flags {
+				if f.internalFlags().persistent() {
+					continue
+				}
+				r.restore = append(r.restore, snapshotOption(f.Value, &f.bs))
+			}
+			for _, a := range sub.Args {
+				r.restore = append(r.restore, snapshotOption(a.Value, &a.bs))
+			}
+			snapshotCommand(sub)
+		}
+	}
+	snapshotCommand(c.Command())
+	return r
+}
+
+func (r *repl) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if r.executeLine(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func (r *repl) runTerminal(f *os.File) error {
+	fd := int(f.Fd())
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{f, r.out}, r.prompt)
+	t.AutoCompleteCallback = r.autoComplete
+	if h, err := loadHistory(r.ctx.App().Name); err == nil {
+		t.History = h
+	}
+
+	for {
+		line, err := r.readLine(fd, t)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if r.executeLine(line) {
+			return nil
+		}
+	}
+}
+
+func (r *repl) readLine(fd int, t *term.Terminal) (string, error) {
+	// Raw mode is only used while reading so that output from commands
+	// is written normally
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, state)
+	return t.ReadLine()
+}
+
+// executeLine executes the line, returning true if the shell should exit
+func (r *repl) executeLine(line string) bool {
+	args, err := Split(line)
+	if err != nil {
+		fmt.Fprintln(r.ctx.Stderr, err)
+		return false
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		if _, ok := r.ctx.Command().Command(args[0]); !ok {
+			return true
+		}
+	}
+
+	if err := r.execute(args); err != nil && !errors.Is(err, ErrSkipCommand) {
+		fmt.Fprintln(r.ctx.Stderr, err)
+	}
+	return false
+}
+
+func (r *repl) execute(args []string) error {
+	cmd, err := tryFindCommandOrIntercept(r.ctx, args[0], nil)
+	if err != nil {
+		return err
+	}
+	for _, restore := range r.restore {
+		restore()
+	}
+	return r.ctx.newChild(cmd, ActionTiming).Execute(args)
+}
+
+// snapshotOption copies the value and binding state of a flag or arg so that they
+// can be restored.  Slices and maps are copied, but values which they contain are
+// not.
+func snapshotOption(value any, bs *BindingState) func() {
+	restoreValue := snapshotValue(value)
+	saved := *bs
+	restoreBinding := snapshotValue(saved)
+	return func() {
+		restoreValue()
+		*bs = saved
+		restoreBinding()
+	}
+}
+
+func snapshotValue(v any) func() {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return func() {}
+	}
+	saved := cloneShallow(rv.Elem())
+	return func() {
+		rv.Elem().Set(cloneShallow(saved))
+	}
+}
+
+func cloneShallow(v reflect.Value) reflect.Value {
+	res := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Slice:
+		if !v.IsNil() {
+			res.Set(reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			res.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				res.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+	default:
+		res.Set(v)
+	}
+	return res
+}
+
+func (r *repl) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	prefix := line[:pos]
+	words, err := Split(prefix)
+	if err != nil {
+		return "", 0, false
+	}
+	var incomplete string
+	if len(words) > 0 && !strings.HasSuffix(prefix, " ") {
+		incomplete = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	items := r.ctx.Complete(append([]string{r.ctx.Name()}, words...), incomplete)
+	completion, ok := commonCompletion(items)
+	if !ok || !strings.HasPrefix(completion, incomplete) {
+		return "", 0, false
+	}
+
+	newPrefix := prefix[:len(prefix)-len(incomplete)] + completion
+	return newPrefix + line[pos:], len(newPrefix), true
+}
+
+// commonCompletion gets the longest common prefix of the token completions.  When
+// there is exactly one, a space is appended unless prevented.
+func commonCompletion(items []CompletionItem) (string, bool) {
+	var values []string
+	var preventSpace bool
+	for _, item := range items {
+		if item.Type != CompletionTypeToken {
+			continue
+		}
+		values = append(values, item.Value)
+		preventSpace = item.PreventSpaceAfter
+	}
+
+	switch len(values) {
+	case 0:
+		return "", false
+	case 1:
+		if preventSpace {
+			return values[0], true
+		}
+		return values[0] + " ", true
+	}
+
+	common := []rune(values[0])
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, string(common)) {
+			common = common[:len(common)-1]
+		}
+	}
+	return string(common), true
+}
+
+func loadHistory(appName string) (*historyFile, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &historyFile{
+		path: filepath.Join(dir, appName, "history"),
+	}
+	data, err := os.ReadFile(h.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for line := range strings.Lines(string(data)) {
+		h.append(strings.TrimSuffix(line, "\n"))
+	}
+	return h, nil
+}
+
+func (h *historyFile) append(entry string) {
+	if entry == "" {
+		return
+	}
+	h.lines = append(h.lines, entry)
+	if len(h.lines) > maxHistory {
+		h.lines = h.lines[len(h.lines)-maxHistory:]
+	}
+}
+
+func (h *historyFile) Add(entry string) {
+	h.append(entry)
+
+	// Failure to persist history is not fatal to the shell
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, entry)
+}
+
+func (h *historyFile) Len() int {
+	return len(h.lines)
+}
+
+func (h *historyFile) At(idx int) string {
+	return h.lines[len(h.lines)-1-idx]
+}
+
+var _ term.History = (*historyFile)(nil)
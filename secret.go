@@ -0,0 +1,152 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Redacted is the text displayed in place of a secret value
+const Redacted = "[redacted]"
+
+// SecretValue provides a value for sensitive text such as passwords and tokens.
+// The text is never displayed: String, GoString, MarshalText, and LogValue all
+// provide Redacted, so the value is masked in help, in dumps of the command line
+// model, and in structured logs.  Use Reveal or Bytes to obtain the text
+// explicitly.  The text is stored as bytes which can be overwritten with zeros
+// using Zero once it is no longer needed.
+//
+// When looked up from the context, the value is the *SecretValue itself
+// rather than its text.  Use Reveal or Bytes to obtain the text.
+type SecretValue struct {
+	data []byte
+}
+
+// Secret creates a secret value
+func Secret() *SecretValue {
+	return new(SecretValue)
+}
+
+// Set sets the text of the secret, replacing and zeroing the previous text
+func (s *SecretValue) Set(arg string) error {
+	s.setBytes([]byte(arg))
+	return nil
+}
+
+// SetReader reads the secret from the reader.  A single trailing new line
+// is removed.
+func (s *SecretValue) SetReader(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	s.setBytes(data)
+	return nil
+}
+
+// ReadFD reads the secret from the file descriptor, which is closed afterwards.
+// This supports the convention of passing secrets to a process using an
+// inherited file descriptor rather than an argument or environment variable.
+func (s *SecretValue) ReadFD(fd uintptr) error {
+	f := os.NewFile(fd, "secret")
+	defer f.Close()
+	return s.SetReader(f)
+}
+
+// ReadPassword prompts for the secret using Context.ReadPasswordString, which
+// requires that Stdin is a terminal
+func (s *SecretValue) ReadPassword(c *Context, prompt string) error {
+	text, err := c.ReadPasswordString(prompt)
+	if err != nil {
+		return err
+	}
+	return s.Set(text)
+}
+
+// Reveal obtains the text of the secret
+func (s *SecretValue) Reveal() string {
+	return string(s.data)
+}
+
+// Bytes obtains the bytes of the secret.  The slice is shared with the value,
+// so it is overwritten by Zero.
+func (s *SecretValue) Bytes() []byte {
+	return s.data
+}
+
+// IsSet determines whether the secret has been set to non-empty text
+func (s *SecretValue) IsSet() bool {
+	return len(s.data) > 0
+}
+
+// Zero overwrites the bytes of the secret with zeros and empties the value
+func (s *SecretValue) Zero() {
+	clear(s.data)
+	s.data = nil
+}
+
+// Reset zeros the value
+func (s *SecretValue) Reset() {
+	s.Zero()
+}
+
+// Copy creates a copy of the secret
+func (s *SecretValue) Copy() *SecretValue {
+	return &SecretValue{
+		data: bytes.Clone(s.data),
+	}
+}
+
+// String provides Redacted when the secret is set; otherwise, the empty string
+func (s *SecretValue) String() string {
+	if s == nil || !s.IsSet() {
+		return ""
+	}
+	return Redacted
+}
+
+// GoString provides the same text as String so that the secret isn't
+// displayed by the %#v verb
+func (s *SecretValue) GoString() string {
+	return s.String()
+}
+
+// MarshalText provides the same text as String so that the secret isn't
+// displayed by encoders
+func (s *SecretValue) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText sets the text of the secret
+func (s *SecretValue) UnmarshalText(b []byte) error {
+	s.setBytes(bytes.Clone(b))
+	return nil
+}
+
+// LogValue provides the same text as String so that the secret isn't
+// displayed in structured logs
+func (s *SecretValue) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// Synopsis obtains the synopsis text
+func (*SecretValue) Synopsis() string {
+	return "SECRET"
+}
+
+func (s *SecretValue) setBytes(data []byte) {
+	s.Zero()
+	s.data = data
+}
+
+var (
+	_ ValueReader    = (*SecretValue)(nil)
+	_ slog.LogValuer = (*SecretValue)(nil)
+)
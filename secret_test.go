@@ -0,0 +1,108 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret", func() {
+
+	It("obtains the secret from the context", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "token", Value: cli.Secret()},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app --token hunter2")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		secret := captured.Value("token").(*cli.SecretValue)
+		Expect(secret.Reveal()).To(Equal("hunter2"))
+	})
+
+	DescribeTable("redacts the text",
+		func(format func(*cli.SecretValue) string) {
+			secret := cli.Secret()
+			_ = secret.Set("hunter2")
+			Expect(format(secret)).To(ContainSubstring(cli.Redacted))
+			Expect(format(secret)).NotTo(ContainSubstring("hunter2"))
+		},
+		Entry("String", (*cli.SecretValue).String),
+		Entry("%v", func(s *cli.SecretValue) string { return fmt.Sprintf("%v", s) }),
+		Entry("%#v", func(s *cli.SecretValue) string { return fmt.Sprintf("%#v", s) }),
+		Entry("JSON", func(s *cli.SecretValue) string {
+			data, _ := json.Marshal(map[string]any{"token": s})
+			return string(data)
+		}),
+	)
+
+	It("sets the text with UnmarshalText", func() {
+		var actual map[string]*cli.SecretValue
+		Expect(json.Unmarshal([]byte(`{"token":"hunter2"}`), &actual)).To(Succeed())
+		Expect(actual["token"].Reveal()).To(Equal("hunter2"))
+		Expect(actual["token"].Bytes()).To(Equal([]byte("hunter2")))
+	})
+
+	It("is empty text when unset", func() {
+		Expect(cli.Secret().String()).To(BeEmpty())
+	})
+
+	It("does not display default text in help", func() {
+		secret := cli.Secret()
+		_ = secret.Set("hunter2")
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "token", Value: secret},
+			},
+		}
+
+		screen := renderScreen(app, "app --help")
+		Expect(screen).To(ContainSubstring("--token=SECRET"))
+		Expect(screen).NotTo(ContainSubstring("hunter2"))
+	})
+
+	It("zeroes the bytes", func() {
+		secret := cli.Secret()
+		_ = secret.Set("hunter2")
+		data := secret.Bytes()
+
+		secret.Zero()
+		Expect(data).To(Equal(make([]byte, len("hunter2"))))
+		Expect(secret.IsSet()).To(BeFalse())
+	})
+
+	It("reads from a reader without the trailing new line", func() {
+		secret := cli.Secret()
+		Expect(cli.SetData(secret, strings.NewReader("hunter2\n"))).To(Succeed())
+		Expect(secret.Reveal()).To(Equal("hunter2"))
+	})
+
+	It("reads from a file descriptor", func() {
+		r, w, err := os.Pipe()
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Fprintln(w, "hunter2")
+		w.Close()
+
+		secret := cli.Secret()
+		Expect(secret.ReadFD(r.Fd())).To(Succeed())
+		Expect(secret.Reveal()).To(Equal("hunter2"))
+	})
+})
@@ -0,0 +1,507 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as described by semver.org.  When parsed,
+// a leading v is allowed, and the minor and patch versions can be omitted,
+// so v1.4, 1.4, and 1.4.0 are all the same version.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// VersionConstraint is a constraint on semantic versions such as >=1.2 <2.
+// Comparisons within the constraint which are separated by spaces or commas must
+// all be satisfied, and alternatives are separated by ||.  The following operators
+// are supported:
+//
+//   - = or no operator, which matches all versions that start with the version,
+//     so 1.2 matches 1.2.0 and 1.2.7 but 1.2.0 matches only 1.2.0.  The last
+//     component can also be x or *
+//   - != to match all other versions
+//   - <, <=, >, and >= to compare versions
+//   - ~ to allow patch versions, so ~1.2.3 means >=1.2.3 <1.3.0
+//   - ^ to allow versions without breaking changes, so ^1.2.3 means >=1.2.3 <2.0.0
+//     and ^0.2.3 means >=0.2.3 <0.3.0
+type VersionConstraint struct {
+	text string
+	any  [][]versionComparison
+}
+
+// SemVerValue provides a value which parses a semantic version (see Version)
+type SemVerValue struct {
+	value Version
+}
+
+// SemVerConstraintValue provides a value which parses a constraint on semantic
+// versions (see VersionConstraint)
+type SemVerConstraintValue struct {
+	value VersionConstraint
+}
+
+type versionComparison struct {
+	op      string
+	version Version
+}
+
+// SemVer creates a semantic version value
+func SemVer() *SemVerValue {
+	return new(SemVerValue)
+}
+
+// SemVerConstraint creates a semantic version constraint value
+func SemVerConstraint() *SemVerConstraintValue {
+	return new(SemVerConstraintValue)
+}
+
+// SemVerCompletion provides completion of the versions that are known.
+// The versions are sorted from newest to oldest, and versions which are not valid
+// are omitted.
+func SemVerCompletion(known func(*Context) []string) Completion {
+	return TokenGeneratorCompletion(func(c *Context) []string {
+		versions := make([]Version, 0)
+		for _, s := range known(c) {
+			if v, err := ParseVersion(s); err == nil {
+				versions = append(versions, v)
+			}
+		}
+		slices.SortFunc(versions, func(x, y Version) int {
+			return y.Compare(x)
+		})
+
+		res := make([]string, len(versions))
+		for i, v := range versions {
+			res[i] = v.String()
+		}
+		return res
+	})
+}
+
+// RequireVersion provides an action which checks that the version of the app
+// satisfies the constraint, such as >=2.0.  This is typically used to require
+// a minimum version for a command or plugin.  The check occurs in the Before
+// pipeline.  When the app does not specify its Version, as in a development build,
+// the check is skipped.  If the constraint is not valid, this function panics.
+func RequireVersion(constraint string) Action {
+	vc := MustParseVersionConstraint(constraint)
+	return Before(ActionFunc(func(c *Context) error {
+		app := c.App()
+		if app.Version == "" || app.versionDefaulted {
+			return nil
+		}
+		text := app.Version
+		v, err := ParseVersion(text)
+		if err != nil {
+			return fmt.Errorf("requires version %s, but app version %q is not valid", vc, text)
+		}
+		if !vc.Check(v) {
+			return fmt.Errorf("requires version %s (current version is %s)", vc, text)
+		}
+		return nil
+	}))
+}
+
+// ParseVersion parses a semantic version
+func ParseVersion(s string) (Version, error) {
+	v, _, wildcard, err := parsePartialVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if wildcard {
+		return Version{}, fmt.Errorf("not a valid version: %s", s)
+	}
+	return v, nil
+}
+
+// MustParseVersion parses a semantic version, panicking if an error occurs
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseVersionConstraint parses a semantic version constraint
+func ParseVersionConstraint(s string) (VersionConstraint, error) {
+	res := VersionConstraint{text: strings.TrimSpace(s)}
+	for _, alt := range strings.Split(s, "||") {
+		var all []versionComparison
+		for _, f := range splitConstraint(alt) {
+			c, err := parseVersionComparisons(f)
+			if err != nil {
+				return VersionConstraint{}, err
+			}
+			all = append(all, c...)
+		}
+		if len(all) == 0 {
+			return VersionConstraint{}, fmt.Errorf("not a valid version constraint: %s", s)
+		}
+		res.any = append(res.any, all)
+	}
+	return res, nil
+}
+
+// MustParseVersionConstraint parses a semantic version constraint, panicking if an error occurs
+func MustParseVersionConstraint(s string) VersionConstraint {
+	v, err := ParseVersionConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compare compares the versions, returning -1, 0, or +1 when v is less than, equal
+// to, or greater than other.  Build metadata is ignored, and prerelease versions have
+// lower precedence than the associated normal version.
+func (v Version) Compare(other Version) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// Less determines whether v is less than the other version
+func (v Version) Less(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Satisfies determines whether the version satisfies the constraint
+func (v Version) Satisfies(c VersionConstraint) bool {
+	return c.Check(v)
+}
+
+// IsZero determines whether the version is 0.0.0 without prerelease or build
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// String formats the version
+func (v Version) String() string {
+	res := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		res += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		res += "+" + v.Build
+	}
+	return res
+}
+
+// Check determines whether the version satisfies the constraint.  The empty
+// constraint is satisfied by all versions.
+func (c VersionConstraint) Check(v Version) bool {
+	if len(c.any) == 0 {
+		return true
+	}
+	for _, all := range c.any {
+		if slices.IndexFunc(all, func(vc versionComparison) bool { return !vc.check(v) }) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String obtains the text of the constraint
+func (c VersionConstraint) String() string {
+	return c.text
+}
+
+// Set will parse the version
+func (s *SemVerValue) Set(arg string) error {
+	v, err := ParseVersion(arg)
+	if err != nil {
+		return err
+	}
+	s.value = v
+	return nil
+}
+
+// String formats the version
+func (s *SemVerValue) String() string {
+	if s.value.IsZero() {
+		return ""
+	}
+	return s.value.String()
+}
+
+// Value obtains the version
+func (s *SemVerValue) Value() Version {
+	return s.value
+}
+
+// Reset sets the value back to the zero value
+func (s *SemVerValue) Reset() {
+	s.value = Version{}
+}
+
+// Copy creates a copy of the value
+func (s *SemVerValue) Copy() *SemVerValue {
+	res := *s
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*SemVerValue) Synopsis() string {
+	return "VERSION"
+}
+
+func (s *SemVerValue) setDirect(v any) error {
+	s.value = v.(Version)
+	return nil
+}
+
+// Set will parse the constraint
+func (s *SemVerConstraintValue) Set(arg string) error {
+	v, err := ParseVersionConstraint(arg)
+	if err != nil {
+		return err
+	}
+	s.value = v
+	return nil
+}
+
+// String obtains the text of the constraint
+func (s *SemVerConstraintValue) String() string {
+	return s.value.String()
+}
+
+// Value obtains the constraint
+func (s *SemVerConstraintValue) Value() VersionConstraint {
+	return s.value
+}
+
+// Reset sets the value back to the zero value
+func (s *SemVerConstraintValue) Reset() {
+	s.value = VersionConstraint{}
+}
+
+// Copy creates a copy of the value
+func (s *SemVerConstraintValue) Copy() *SemVerConstraintValue {
+	res := *s
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*SemVerConstraintValue) Synopsis() string {
+	return "CONSTRAINT"
+}
+
+func (s *SemVerConstraintValue) setDirect(v any) error {
+	s.value = v.(VersionConstraint)
+	return nil
+}
+
+func (vc versionComparison) check(v Version) bool {
+	c := v.Compare(vc.version)
+	switch vc.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default: // ">="
+		return c >= 0
+	}
+}
+
+// splitConstraint splits on spaces and commas, but keeps an operator separated
+// by spaces from its version together, as in >= 1.2
+func splitConstraint(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+	res := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Trim(f, "=<>!~^") == "" && i+1 < len(fields) {
+			f += fields[i+1]
+			i++
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+func parseVersionComparisons(s string) ([]versionComparison, error) {
+	op := s[:len(s)-len(strings.TrimLeft(s, "=<>!~^"))]
+	text := s[len(op):]
+	v, n, _, err := parsePartialVersion(text)
+	if err != nil {
+		return nil, fmt.Errorf("not a valid version constraint: %s", s)
+	}
+	if n == 0 {
+		return []versionComparison{{">=", Version{}}}, nil
+	}
+
+	// n is the number of components which were specified, or -1 when
+	// all were.  An upper bound is obtained by incrementing the last one.
+	next := func(n int) Version {
+		switch n {
+		case 0:
+			return Version{}
+		case 1:
+			return Version{Major: v.Major + 1}
+		default:
+			return Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+	}
+	between := func(lo, hi Version) []versionComparison {
+		if hi.IsZero() {
+			return []versionComparison{{">=", lo}}
+		}
+		return []versionComparison{{">=", lo}, {"<", hi}}
+	}
+
+	switch op {
+	case "", "=", "==":
+		if n < 0 {
+			return []versionComparison{{"=", v}}, nil
+		}
+		return between(v, next(n)), nil
+	case "!=":
+		if n >= 0 {
+			return nil, fmt.Errorf("not a valid version constraint: %s", s)
+		}
+		return []versionComparison{{"!=", v}}, nil
+	case ">", "<=":
+		if n < 0 {
+			return []versionComparison{{op, v}}, nil
+		}
+		// >1.2 means >=1.3.0 and <=1.2 means <1.3.0
+		if op == ">" {
+			return []versionComparison{{">=", next(n)}}, nil
+		}
+		return []versionComparison{{"<", next(n)}}, nil
+	case ">=", "<":
+		return []versionComparison{{op, v}}, nil
+	case "~":
+		if n == 1 {
+			return between(v, next(1)), nil
+		}
+		return between(v, next(2)), nil
+	case "^":
+		switch {
+		case v.Major > 0 || n == 1:
+			return between(v, next(1)), nil
+		case v.Minor > 0 || n == 2:
+			return between(v, next(2)), nil
+		default:
+			return between(v, Version{Patch: v.Patch + 1}), nil
+		}
+	}
+	return nil, fmt.Errorf("not a valid version constraint: %s", s)
+}
+
+// parsePartialVersion parses a version, which can omit trailing components or
+// use x or * for them.  The number of components that were specified is returned,
+// or -1 when the version was complete.
+func parsePartialVersion(s string) (res Version, n int, wildcard bool, err error) {
+	text := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	text, build, hasBuild := strings.Cut(text, "+")
+	text, prerelease, hasPrerelease := strings.Cut(text, "-")
+	res.Build, res.Prerelease = build, prerelease
+
+	invalid := fmt.Errorf("not a valid version: %s", s)
+	parts := strings.Split(text, ".")
+	if len(parts) > 3 || text == "" {
+		return Version{}, 0, false, invalid
+	}
+	if !validVersionIdentifiers(prerelease, hasPrerelease) || !validVersionIdentifiers(build, hasBuild) {
+		return Version{}, 0, false, invalid
+	}
+
+	fields := []*uint64{&res.Major, &res.Minor, &res.Patch}
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			if res.Prerelease != "" || res.Build != "" {
+				return Version{}, 0, false, invalid
+			}
+			return res, i, true, nil
+		}
+		value, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, 0, false, invalid
+		}
+		*fields[i] = value
+	}
+	if len(parts) < 3 {
+		return res, len(parts), false, nil
+	}
+	return res, -1, false, nil
+}
+
+// validVersionIdentifiers checks the dot-separated identifiers of the prerelease
+// or build, which must not be empty when present
+func validVersionIdentifiers(s string, present bool) bool {
+	if !present {
+		return true
+	}
+	for _, ident := range strings.Split(s, ".") {
+		if ident == "" || strings.Trim(ident, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func comparePrerelease(x, y string) int {
+	switch {
+	case x == y:
+		return 0
+	case x == "":
+		return 1
+	case y == "":
+		return -1
+	}
+
+	xs, ys := strings.Split(x, "."), strings.Split(y, ".")
+	for i := 0; i < len(xs) && i < len(ys); i++ {
+		xn, xerr := strconv.ParseUint(xs[i], 10, 64)
+		yn, yerr := strconv.ParseUint(ys[i], 10, 64)
+		var c int
+		switch {
+		case xerr == nil && yerr == nil:
+			c = cmp.Compare(xn, yn)
+		case xerr == nil:
+			c = -1
+		case yerr == nil:
+			c = 1
+		default:
+			c = strings.Compare(xs[i], ys[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(xs), len(ys))
+}
+
+var (
+	_ Value = (*SemVerValue)(nil)
+	_ Value = (*SemVerConstraintValue)(nil)
+)
@@ -0,0 +1,194 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/bind"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SemVer", func() {
+
+	DescribeTable("parsing",
+		func(text string, expected cli.Version) {
+			v, err := cli.ParseVersion(text)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(expected))
+		},
+		Entry("complete", "1.4.0", cli.Version{Major: 1, Minor: 4}),
+		Entry("v prefix", "v1.4.2", cli.Version{Major: 1, Minor: 4, Patch: 2}),
+		Entry("partial", "2", cli.Version{Major: 2}),
+		Entry("prerelease", "1.0.0-rc.1", cli.Version{Major: 1, Prerelease: "rc.1"}),
+		Entry("build", "1.0.0+20240301", cli.Version{Major: 1, Build: "20240301"}),
+	)
+
+	DescribeTable("parsing errors",
+		func(text string) {
+			_, err := cli.ParseVersion(text)
+			Expect(err).To(MatchError("not a valid version: " + text))
+		},
+		Entry("empty", ""),
+		Entry("too many components", "1.2.3.4"),
+		Entry("not a number", "1.a"),
+		Entry("wildcard", "1.x"),
+		Entry("empty prerelease", "1.2.3-"),
+		Entry("empty build", "1.2.3+"),
+		Entry("empty prerelease identifier", "1.2.3-alpha..1"),
+	)
+
+	DescribeTable("comparison",
+		func(x, y string, expected int) {
+			Expect(cli.MustParseVersion(x).Compare(cli.MustParseVersion(y))).To(Equal(expected))
+		},
+		Entry("equal", "1.2.3", "v1.2.3", 0),
+		Entry("major", "2.0.0", "1.9.9", 1),
+		Entry("minor", "1.2.0", "1.10.0", -1),
+		Entry("prerelease before release", "1.0.0-alpha", "1.0.0", -1),
+		Entry("numeric prerelease", "1.0.0-rc.2", "1.0.0-rc.10", -1),
+		Entry("alphanumeric prerelease", "1.0.0-alpha", "1.0.0-beta", -1),
+		Entry("build ignored", "1.0.0+a", "1.0.0+b", 0),
+	)
+
+	DescribeTable("constraints",
+		func(constraint, version string, expected bool) {
+			vc, err := cli.ParseVersionConstraint(constraint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vc.Check(cli.MustParseVersion(version))).To(Equal(expected))
+		},
+		Entry("range lower", ">=1.2 <2", "1.2.0", true),
+		Entry("range upper", ">=1.2 <2", "2.0.0", false),
+		Entry("range with commas", ">=1.2, <2", "1.9.0", true),
+		Entry("operator with space", ">= 1.2", "1.1.0", false),
+		Entry("exact", "1.2.3", "1.2.3", true),
+		Entry("partial exact", "1.2", "1.2.9", true),
+		Entry("partial exact excludes", "1.2", "1.3.0", false),
+		Entry("wildcard", "1.x", "1.9.0", true),
+		Entry("not equal", "!=1.2.3", "1.2.3", false),
+		Entry("greater than partial", ">1.2", "1.2.9", false),
+		Entry("less or equal partial", "<=1.2", "1.2.9", true),
+		Entry("tilde", "~1.2.3", "1.2.9", true),
+		Entry("tilde upper", "~1.2.3", "1.3.0", false),
+		Entry("caret", "^1.2.3", "1.9.0", true),
+		Entry("caret upper", "^1.2.3", "2.0.0", false),
+		Entry("caret zero major", "^0.2.3", "0.3.0", false),
+		Entry("alternatives", "<1 || >=3", "3.1.0", true),
+		Entry("alternatives excluded", "<1 || >=3", "2.0.0", false),
+	)
+
+	It("returns an error for an invalid constraint", func() {
+		_, err := cli.ParseVersionConstraint(">=one")
+		Expect(err).To(MatchError("not a valid version constraint: >=one"))
+	})
+
+	It("obtains values from the context", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "target", Value: cli.SemVer()},
+				{Name: "version-constraint", Value: cli.SemVerConstraint()},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app --target v1.4.0 --version-constraint '>=1.2 <2'")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		target := captured.Value("target").(cli.Version)
+		constraint := captured.Value("version-constraint").(cli.VersionConstraint)
+		Expect(target.String()).To(Equal("1.4.0"))
+		Expect(target.Satisfies(constraint)).To(BeTrue())
+	})
+
+	It("completes known versions from newest", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{
+					Name:  "target",
+					Value: cli.SemVer(),
+					Completion: cli.SemVerCompletion(func(*cli.Context) []string {
+						return []string{"1.2.0", "v1.10.0", "not-a-version", "1.9.0"}
+					}),
+				},
+			},
+			Action: func() {},
+		}
+
+		args, _ := cli.Split("app --target")
+		ctx, err := app.Initialize(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cli.FromContext(ctx).Complete(args, "")).To(Equal([]cli.CompletionItem{
+			{Value: "1.10.0"},
+			{Value: "1.9.0"},
+			{Value: "1.2.0"},
+		}))
+	})
+
+	It("can be bound", func() {
+		var (
+			version    cli.Version
+			constraint cli.VersionConstraint
+		)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{
+					Name: "version",
+					Uses: bind.Call(func(v cli.Version) error {
+						version = v
+						return nil
+					}, bind.SemVer()),
+				},
+				{
+					Name: "require",
+					Uses: bind.Call(func(v cli.VersionConstraint) error {
+						constraint = v
+						return nil
+					}, bind.SemVerConstraint()),
+				},
+			},
+		}
+
+		args, _ := cli.Split("app --version v1.4 --require '>=1.2 <2'")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(version).To(Equal(cli.Version{Major: 1, Minor: 4}))
+		Expect(constraint.Check(version)).To(BeTrue())
+	})
+
+	DescribeTable("RequireVersion",
+		func(version string, expected any) {
+			app := &cli.App{
+				Name:    "app",
+				Version: version,
+				Commands: []*cli.Command{
+					{
+						Name:   "sub",
+						Uses:   cli.RequireVersion(">=2.0"),
+						Action: func() {},
+					},
+				},
+			}
+
+			args, _ := cli.Split("app sub")
+			err := app.RunContext(context.Background(), args)
+			if expected == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expected))
+			}
+		},
+		Entry("satisfied", "2.1.0", nil),
+		Entry("not satisfied", "1.4.0", "requires version >=2.0 (current version is 1.4.0)"),
+		Entry("not valid", "dev", `requires version >=2.0, but app version "dev" is not valid`),
+		Entry("not specified", "", nil),
+	)
+})
@@ -12,15 +12,18 @@ import (
 // BindingResult contains the occurrences of the values passed to each flag and arg
 // along with the input args which produced them.
 type BindingResult struct {
-	args     []string
-	bindings map[string][][]string
-	names    []string
+	args      []string
+	bindings  map[string][][]string
+	positions map[string][]int
+	names     []string
+	offset    int // position of args within the args of the root command
 }
 
 type set struct {
 	Lookup
 	Binding
 	*BindingResult
+	offset int
 }
 
 type bindingImpl struct {
@@ -344,10 +347,13 @@ func RawParse(arguments []string, b Binding, flags RawParseFlag) (bindings *Bind
 	}
 
 	var (
-		state        = flagsOrArgs
-		anyArgs      bool
-		appendOutput = bindings.appendOutput
+		state   = flagsOrArgs
+		anyArgs bool
+		start   int // position of the arg which started the occurrence
 	)
+	appendOutput := func(name string, values []string) {
+		bindings.appendOutput(name, start, values)
+	}
 
 	// Skip program name
 	if !args.empty() && flags.skipProgramName() {
@@ -359,6 +365,7 @@ func RawParse(arguments []string, b Binding, flags RawParseFlag) (bindings *Bind
 
 Parsing:
 	for !args.empty() {
+		start = len(arguments) - len(args)
 		arg := args.pop()
 
 		// end of options?
@@ -399,6 +406,7 @@ Parsing:
 					continue Parsing
 				}
 
+				start = len(arguments) - len(args)
 				arg = args.pop()
 				anyArgs = true
 			}
@@ -547,6 +555,7 @@ Parsing:
 
 func (s *set) parse(args argList, flags RawParseFlag) error {
 	bindings, err := RawParse(args, s.Binding, flags)
+	bindings.offset = s.offset
 	s.BindingResult = bindings
 	if err != nil {
 		return err
@@ -668,8 +677,9 @@ func (s *set) OccurrenceValues(name string) []any {
 
 func newBindingResult(args []string) *BindingResult {
 	return &BindingResult{
-		args:     args,
-		bindings: map[string][][]string{},
+		args:      args,
+		bindings:  map[string][][]string{},
+		positions: map[string][]int{},
 	}
 }
 
@@ -687,15 +697,16 @@ func MergeBindingResults(results ...*BindingResult) *BindingResult {
 			merged.args = m.args
 		}
 		for _, name := range m.names {
-			for _, occur := range m.bindings[name] {
-				merged.appendOutput(name, occur)
+			for i, occur := range m.bindings[name] {
+				merged.appendOutput(name, m.offset+m.positions[name][i], occur)
 			}
 		}
 	}
 	return merged
 }
 
-func (m *BindingResult) appendOutput(name string, args []string) {
+func (m *BindingResult) appendOutput(name string, pos int, args []string) {
+	m.positions[name] = append(m.positions[name], pos)
 	if e, ok := m.bindings[name]; ok {
 		m.bindings[name] = append(e, args)
 		return
@@ -755,10 +766,33 @@ func (m *BindingResult) ApplyTo(b Binding) error {
 		if err != nil {
 			return err
 		}
+		if o, ok := value.(option); ok {
+			source := m.valueSource(name, o.internalFlags().optional())
+			if _, secret := o.value().(*SecretValue); secret {
+				*source = source.redacted()
+			}
+			o.setValueSource(source)
+		}
 	}
 	return nil
 }
 
+// valueSource gets the source of the last occurrence of the flag or arg
+func (m *BindingResult) valueSource(name string, optional bool) *ValueSource {
+	occurs := m.bindings[name]
+	last := len(occurs) - 1
+	raw := occurs[last]
+	kind := ArgsSource
+	if optional && (len(raw) == 1 || raw[1] == "") {
+		kind = OptionalValueSource
+	}
+	return &ValueSource{
+		Kind:     kind,
+		Position: m.offset + m.positions[name][last],
+		Raw:      raw,
+	}
+}
+
 // Bindings obtains values which were specified for a flag or arg
 // including the flag or arg name and grouped into occurrences.
 func (m *BindingResult) Bindings(name string) [][]string {
@@ -0,0 +1,207 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ValueSourceKind enumerates where the value of a flag or arg was obtained
+type ValueSourceKind int
+
+// ValueSource describes where the value of a flag or arg was obtained.  When
+// more than one source provides a value, the source of the value which won
+// is recorded.  The value from the command line always wins over implicit values.
+type ValueSource struct {
+	// Kind identifies the kind of source
+	Kind ValueSourceKind
+
+	// Name provides the name of the environment variable, file path, configuration key,
+	// or flag which implied the value, depending upon the kind.  For values set by
+	// ImplicitValue, this is empty.
+	Name string
+
+	// Layer provides the name of the configuration layer such as user or workspace
+	// when the kind is ConfigSource
+	Layer string
+
+	// Position provides the index of the last occurrence within the command line args
+	// when the kind is ArgsSource or OptionalValueSource.  The program name is index 0.
+	Position int
+
+	// Raw provides the raw occurrence from the command line, including the name of the
+	// flag which was used.  For secret values, the text is replaced with Redacted.
+	Raw []string
+}
+
+// Value source kinds
+const (
+	// DefaultSource indicates the value was not set, so it is the default
+	DefaultSource ValueSourceKind = iota
+
+	// ArgsSource indicates the value was set from the command line
+	ArgsSource
+
+	// OptionalValueSource indicates the flag was specified on the command line
+	// without its value, so the optional value was used (see OptionalValue)
+	OptionalValueSource
+
+	// EnvSource indicates the value was read from an environment variable
+	EnvSource
+
+	// FileSource indicates the value was read from a file
+	FileSource
+
+	// ImpliedSource indicates the value was implied by another flag or set by
+	// ImplicitValue
+	ImpliedSource
+
+	// ConfigSource indicates the value was read from configuration
+	ConfigSource
+)
+
+const debugFlagsFlagName = "debug-flags"
+
+var valueSourceKindStrings = [...]string{
+	DefaultSource:       "default",
+	ArgsSource:          "args",
+	OptionalValueSource: "optional",
+	EnvSource:           "env",
+	FileSource:          "file",
+	ImpliedSource:       "implied",
+	ConfigSource:        "config",
+}
+
+// DebugFlags provides a flag named debug-flags which prints a table of the effective
+// value of each flag and where it was obtained before the command runs, which helps
+// to explain why a flag has the value it does.  The table is written to stderr.  Because
+// the flag is persistent, when a sub-command is executed, the table is printed for the
+// sub-command rather than its parent.  It is typically used in the Uses pipeline of a flag:
+//
+//	Flags: []*cli.Flag{
+//		{Uses: cli.DebugFlags()},
+//	}
+func DebugFlags() Action {
+	return Pipeline(&Prototype{
+		Name:     debugFlagsFlagName,
+		HelpText: "Print the value of each flag and where it was obtained",
+		Value:    Bool(),
+	}, At(ActionTiming, ActionFunc(printValueSources)))
+}
+
+func printValueSources(c *Context) error {
+	cmd := c.Parent()
+	for _, a := range cmd.Command().Args {
+		if a.internalFlags().executesSubcommand() && cmd.Seen(a) {
+			return nil
+		}
+	}
+
+	w := tabwriter.NewWriter(c.Stderr, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "FLAG\tVALUE\tSOURCE")
+	for _, f := range cmd.Flags() {
+		if f == c.Flag() || f.internalFlags().hidden() {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%v\t%s\n", f.LongName(), cmd.Value(f), cmd.ValueSource(f))
+	}
+	return w.Flush()
+}
+
+// redacted gets a copy of the source where the values in the raw occurrence are
+// replaced with Redacted, leaving only the name of the flag
+func (s ValueSource) redacted() ValueSource {
+	raw := slices.Clone(s.Raw)
+	for i, r := range raw {
+		if i == 0 && strings.HasPrefix(r, "-") || r == "" {
+			continue
+		}
+		raw[i] = Redacted
+	}
+	s.Raw = raw
+	return s
+}
+
+// DisplayValueSources causes the help screen to display the sources of the value
+// of each flag after its help text, such as its environment variables and any sources
+// declared with DeclareValueSource.  This is implemented by registering the template
+// named FlagSources, which is otherwise empty.  It is typically used in the Uses
+// pipeline of the app.
+func DisplayValueSources() Action {
+	return RegisterTemplate("", flagSourcesTemplate)
+}
+
+// DeclareValueSource declares an additional source of the value of a flag, which is
+// displayed in help alongside its environment variables when DisplayValueSources is
+// used.  Only the Kind and Name of the source are used.  This is how extensions
+// such as the config extension document the keys they read.  This handler is
+// generally set up inside a Uses pipeline.
+func DeclareValueSource(s ValueSource) Action {
+	return ActionFunc(func(c *Context) error {
+		sources, _ := c.target().LookupData(valueSourcesDataKey)
+		declared, _ := sources.([]ValueSource)
+		c.SetData(valueSourcesDataKey, append(declared, s))
+		return nil
+	})
+}
+
+// flagSources gets the descriptions of the sources of the flag which are displayed
+// in help
+func flagSources(f *Flag) []string {
+	var res []string
+	if len(f.EnvVars) > 0 {
+		name := flagScreamingSnakeCase(f)
+		for _, v := range f.EnvVars {
+			res = append(res, "env: "+expandEnvVarName(v, name))
+		}
+	}
+	declared, _ := f.LookupData(valueSourcesDataKey)
+	sources, _ := declared.([]ValueSource)
+	for _, s := range sources {
+		res = append(res, s.helpText())
+	}
+	return res
+}
+
+func (s ValueSource) helpText() string {
+	if s.Name == "" {
+		return s.Kind.String()
+	}
+	return s.Kind.String() + ": " + s.Name
+}
+
+// String produces a description of the source
+func (s ValueSource) String() string {
+	switch s.Kind {
+	case ArgsSource:
+		return fmt.Sprintf("argv[%d] %s", s.Position, strings.Join(s.Raw, " "))
+	case OptionalValueSource:
+		return fmt.Sprintf("argv[%d] %s (optional value)", s.Position, strings.TrimSpace(strings.Join(s.Raw, " ")))
+	case EnvSource, FileSource:
+		return s.Kind.String() + " " + s.Name
+	case ImpliedSource:
+		if s.Name == "" {
+			return "implied"
+		}
+		return "implied by " + s.Name
+	case ConfigSource:
+		if s.Layer == "" {
+			return "config " + s.Name
+		}
+		return fmt.Sprintf("config %s (%s)", s.Name, s.Layer)
+	}
+	return s.Kind.String()
+}
+
+// String produces a textual representation of the kind
+func (k ValueSourceKind) String() string {
+	if k >= 0 && int(k) < len(valueSourceKindStrings) {
+		return valueSourceKindStrings[k]
+	}
+	return fmt.Sprintf("ValueSourceKind(%d)", int(k))
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing/fstest"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValueSource", func() {
+
+	var sourceOf = func(app *cli.App, arguments string, name string) cli.ValueSource {
+		act := new(joeclifakes.FakeAction)
+		app.Action = act
+
+		args, _ := cli.Split(arguments)
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		return cli.FromContext(act.ExecuteArgsForCall(0)).ValueSource(name)
+	}
+
+	It("records position of the last occurrence on the command line", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "format", EnvVars: []string{"_GOCLI_FORMAT"}},
+				{Name: "verbose", Value: new(bool)},
+			},
+		}
+		os.Setenv("_GOCLI_FORMAT", "yaml")
+		DeferCleanup(os.Unsetenv, "_GOCLI_FORMAT")
+
+		actual := sourceOf(app, "app --format=json --verbose --format text", "format")
+		Expect(actual).To(Equal(cli.ValueSource{
+			Kind:     cli.ArgsSource,
+			Position: 3,
+			Raw:      []string{"--format", "text"},
+		}))
+		Expect(actual.String()).To(Equal("argv[3] --format text"))
+	})
+
+	It("records the environment variable", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "format", EnvVars: []string{"_GOCLI_MISSING", "_GOCLI_FORMAT"}},
+			},
+		}
+		os.Setenv("_GOCLI_FORMAT", "yaml")
+		DeferCleanup(os.Unsetenv, "_GOCLI_FORMAT")
+
+		actual := sourceOf(app, "app", "format")
+		Expect(actual).To(Equal(cli.ValueSource{Kind: cli.EnvSource, Name: "_GOCLI_FORMAT"}))
+		Expect(actual.String()).To(Equal("env _GOCLI_FORMAT"))
+	})
+
+	It("records the file path", func() {
+		app := &cli.App{
+			Name: "app",
+			FS: fstest.MapFS{
+				"format.txt": {Data: []byte("toml")},
+			},
+			Flags: []*cli.Flag{
+				{Name: "format", FilePath: "format.txt"},
+			},
+		}
+
+		actual := sourceOf(app, "app", "format")
+		Expect(actual).To(Equal(cli.ValueSource{Kind: cli.FileSource, Name: "format.txt"}))
+	})
+
+	It("removes the trailing newline from the file", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			FS: fstest.MapFS{
+				"format.txt": {Data: []byte("toml\n")},
+			},
+			Flags: []*cli.Flag{
+				{Name: "format", FilePath: "format.txt"},
+			},
+			Action: act,
+		}
+
+		args, _ := cli.Split("app")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(cli.FromContext(act.ExecuteArgsForCall(0)).String("format")).To(Equal("toml"))
+	})
+
+	It("redacts the raw occurrence of secrets", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "token", Value: cli.Secret()},
+			},
+		}
+
+		actual := sourceOf(app, "app --token s3cret", "token")
+		Expect(actual.Raw).To(Equal([]string{"--token", cli.Redacted}))
+		Expect(actual.String()).To(Equal("argv[1] --token " + cli.Redacted))
+	})
+
+	It("records the flag which implied the value", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "mode"},
+				{Name: "encryption-key", Uses: cli.Implies("mode", "encrypt")},
+			},
+		}
+
+		actual := sourceOf(app, "app --encryption-key k", "mode")
+		Expect(actual).To(Equal(cli.ValueSource{Kind: cli.ImpliedSource, Name: "--encryption-key"}))
+		Expect(actual.String()).To(Equal("implied by --encryption-key"))
+	})
+
+	It("records the optional value", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "secure", Uses: cli.OptionalValue("TLS1.2")},
+			},
+		}
+
+		actual := sourceOf(app, "app --secure", "secure")
+		Expect(actual.Kind).To(Equal(cli.OptionalValueSource))
+		Expect(actual.String()).To(Equal("argv[1] --secure (optional value)"))
+	})
+
+	It("records the position within the args of the root command", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Commands: []*cli.Command{
+				{
+					Name:   "sub",
+					Flags:  []*cli.Flag{{Name: "format"}},
+					Action: act,
+				},
+			},
+		}
+
+		args, _ := cli.Split("app sub --format json")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		actual := cli.FromContext(act.ExecuteArgsForCall(0)).ValueSource("format")
+		Expect(actual.Position).To(Equal(2))
+	})
+
+	It("is the default when not set", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Name: "format"},
+			},
+		}
+
+		actual := sourceOf(app, "app", "format")
+		Expect(actual).To(Equal(cli.ValueSource{}))
+		Expect(actual.String()).To(Equal("default"))
+	})
+
+	Describe("DebugFlags", func() {
+
+		It("prints the values and their sources", func() {
+			var stderr bytes.Buffer
+			app := &cli.App{
+				Name:   "app",
+				Stderr: &stderr,
+				Flags: []*cli.Flag{
+					{Uses: cli.DebugFlags()},
+					{Name: "format"},
+					{Name: "mode"},
+					{Name: "encryption-key", Uses: cli.Implies("mode", "encrypt")},
+				},
+				Action: func() {},
+			}
+
+			args, _ := cli.Split("app --encryption-key k --debug-flags")
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			Expect(stderr.String()).To(HavePrefix(
+				"FLAG              VALUE    SOURCE\n" +
+					"--format                   default\n" +
+					"--mode            encrypt  implied by --encryption-key\n" +
+					"--encryption-key  k        argv[1] --encryption-key k\n",
+			))
+		})
+
+		It("redacts secrets", func() {
+			var stderr bytes.Buffer
+			app := &cli.App{
+				Name:   "app",
+				Stderr: &stderr,
+				Flags: []*cli.Flag{
+					{Uses: cli.DebugFlags()},
+					{Name: "token", Value: cli.Secret()},
+					{Name: "password", Value: cli.Secret()},
+				},
+				Action: func() {},
+			}
+
+			args, _ := cli.Split("app --debug-flags --token s3cret --password=hunter2")
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			Expect(stderr.String()).NotTo(ContainSubstring("s3cret"))
+			Expect(stderr.String()).NotTo(ContainSubstring("hunter2"))
+			Expect(stderr.String()).To(ContainSubstring("--token     [redacted]  argv[2] --token [redacted]"))
+		})
+
+		It("prints only for the sub-command", func() {
+			var stderr bytes.Buffer
+			app := &cli.App{
+				Name:   "app",
+				Stderr: &stderr,
+				Flags: []*cli.Flag{
+					{Uses: cli.DebugFlags()},
+				},
+				Commands: []*cli.Command{
+					{
+						Name:   "sub",
+						Flags:  []*cli.Flag{{Name: "format"}},
+						Action: func() {},
+					},
+				},
+			}
+
+			args, _ := cli.Split("app --debug-flags sub --format json")
+			Expect(app.RunContext(context.Background(), args)).To(Succeed())
+			Expect(strings.Count(stderr.String(), "FLAG")).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("--format   json   argv[3] --format json"))
+		})
+	})
+})
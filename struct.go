@@ -0,0 +1,350 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	valueType = reflect.TypeFor[Value]()
+
+	// structValueTypes provides the values used for fields whose type is a struct
+	// that has a corresponding value
+	structValueTypes = map[reflect.Type]func() Value{
+		reflect.TypeFor[time.Time]():      func() Value { return Time() },
+		reflect.TypeFor[netip.Addr]():     func() Value { return Addr() },
+		reflect.TypeFor[netip.AddrPort](): func() Value { return AddrPort() },
+		reflect.TypeFor[netip.Prefix]():   func() Value { return IPNet() },
+	}
+)
+
+// structFieldValueAdapter adapts a value so that it updates the field of a struct
+type structFieldValueAdapter struct {
+	value Value
+	dest  reflect.Value
+}
+
+type structField struct {
+	name     string
+	aliases  []string
+	envVars  []string
+	help     string
+	category string
+	prefix   string
+	required bool
+	hidden   bool
+	arg      bool
+	skip     bool
+}
+
+// FlagsFromStruct creates flags which are bound to the fields of a struct.  The
+// argument must be a pointer to a struct.  Each exported field provides a flag
+// unless the field is tagged as an arg or with cli:"-".  The type of each field must be
+// one of the types supported for Flag.Value (the pointer to the field is used as the value),
+// or it must be a pointer to an implementation of Value.  When such a pointer is nil, it is
+// allocated using new, so initialize the field if the zero value isn't valid.  Fields of
+// type time.Time, netip.Addr, netip.AddrPort, and netip.Prefix use the corresponding
+// values (see Time, Addr, AddrPort, and IPNet).  A field which is a pointer to a struct
+// is allocated if it is nil and is used in the same way as the struct.
+//
+// The cli struct tag contains a comma-separated list of settings:
+//
+//   - name=NAME sets the name of the flag, which otherwise is derived from the name of
+//     the field, so OutputDir is output-dir
+//   - alias=ALIAS adds an alias.  This can be specified more than once
+//   - env=VAR adds an environment variable.  This can be specified more than once
+//   - help=TEXT sets the help text.  Use single quotes if the text contains commas
+//   - category=CATEGORY sets the category
+//   - required marks the flag as required
+//   - hidden marks the flag as hidden
+//   - arg indicates that the field provides an arg instead of a flag (see ArgsFromStruct)
+//   - prefix=PREFIX sets the prefix used for the flags of a nested struct
+//
+// A field which is a nested struct provides a group of flags.  The names of the flags
+// in the group start with the prefix, which is otherwise derived from the field name, so a
+// field Server with a field URL provides the flag --server-url.  The category of the
+// group applies to flags which don't specify their own.  Embedded structs provide flags
+// without a prefix, even when the embedded type is not exported.
+//
+// If v is not a pointer to a struct or a field type is not supported, the function panics.
+// The initial value of each field is used as the default value of the flag.
+func FlagsFromStruct(v any) []*Flag {
+	var res []*Flag
+	walkStruct(v, func(f *structField, value any) {
+		if f.arg {
+			return
+		}
+		flag := &Flag{
+			Name:     f.name,
+			Aliases:  f.aliases,
+			EnvVars:  f.envVars,
+			HelpText: f.help,
+			Category: f.category,
+			Value:    value,
+		}
+		if f.required {
+			flag.Options |= Required
+		}
+		if f.hidden {
+			flag.Options |= Hidden
+		}
+		res = append(res, flag)
+	})
+	return res
+}
+
+// ArgsFromStruct creates args which are bound to the fields of a struct which are
+// tagged with arg.  The args are in the order of the fields.  The same tag settings are
+// used as for FlagsFromStruct, except that alias and prefix do not apply.
+func ArgsFromStruct(v any) []*Arg {
+	var res []*Arg
+	walkStruct(v, func(f *structField, value any) {
+		if !f.arg {
+			return
+		}
+		arg := &Arg{
+			Name:     f.name,
+			EnvVars:  f.envVars,
+			HelpText: f.help,
+			Category: f.category,
+			Value:    value,
+		}
+		if f.required {
+			arg.Options |= Required
+		}
+		if f.hidden {
+			arg.Options |= Hidden
+		}
+		res = append(res, arg)
+	})
+	return res
+}
+
+func walkStruct(v any, fn func(*structField, any)) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Pointer || val.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected pointer to struct, but got %T", v))
+	}
+	walkStructFields(val.Elem(), &structField{}, fn)
+}
+
+func walkStructFields(val reflect.Value, group *structField, fn func(*structField, any)) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		f, err := parseStructTag(sf)
+		if err != nil {
+			panic(err)
+		}
+		if f.skip {
+			continue
+		}
+		if f.category == "" {
+			f.category = group.category
+		}
+
+		field := val.Field(i)
+		value, ok := structFieldValue(field, sf.IsExported())
+		if !ok && sf.IsExported() && field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct {
+			// A pointer to a struct is allocated if necessary and used like the struct
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+			value, ok = structFieldValue(field, true)
+		}
+		if ok {
+			f.name = group.prefix + f.name
+			fn(f, value)
+			continue
+		}
+
+		if field.Kind() == reflect.Struct && hasExportedFields(field.Type()) {
+			nested := &structField{
+				category: f.category,
+				prefix:   group.prefix,
+			}
+			if !sf.Anonymous {
+				nested.prefix += f.prefix + "-"
+			}
+			walkStructFields(field, nested, fn)
+			continue
+		}
+		panic(fmt.Sprintf("unsupported type %s for field %s", sf.Type, sf.Name))
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func structFieldValue(field reflect.Value, exported bool) (any, bool) {
+	if !exported {
+		return nil, false
+	}
+
+	// A pointer to an implementation of Value is used directly, allocating
+	// it if necessary
+	if field.Kind() == reflect.Pointer && field.Type().Implements(valueType) {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return field.Interface(), true
+	}
+
+	if newValue, ok := structValueTypes[field.Type()]; ok {
+		res := &structFieldValueAdapter{value: newValue(), dest: field}
+		if !field.IsZero() {
+			_ = res.value.(valueSetDirect).setDirect(field.Interface())
+		}
+		return res, true
+	}
+
+	ptr := field.Addr().Interface()
+	if checkSupportedFlagType(ptr) != nil {
+		return nil, false
+	}
+	return ptr, true
+}
+
+func (a *structFieldValueAdapter) Set(arg string) error {
+	if err := a.value.Set(arg); err != nil {
+		return err
+	}
+	a.sync()
+	return nil
+}
+
+func (a *structFieldValueAdapter) String() string {
+	return a.value.String()
+}
+
+func (a *structFieldValueAdapter) Value() any {
+	return dereference(a.value)
+}
+
+func (a *structFieldValueAdapter) Synopsis() string {
+	return a.value.(interface{ Synopsis() string }).Synopsis()
+}
+
+func (a *structFieldValueAdapter) Reset() {
+	a.value.(valueResetOrMerge).Reset()
+	a.dest.SetZero()
+}
+
+// Initializer obtains the initializer of the value.  Because the initializer can
+// update the value, the field is updated again in the Before pipeline.
+func (a *structFieldValueAdapter) Initializer() Action {
+	var init Action = Pipeline()
+	if i, ok := a.value.(valueInitializer); ok {
+		init = i.Initializer()
+	}
+	return Pipeline(init, ActionFunc(func(c *Context) error {
+		return c.Before(ActionOf(a.sync))
+	}))
+}
+
+func (a *structFieldValueAdapter) sync() {
+	a.dest.Set(reflect.ValueOf(a.Value()))
+}
+
+func parseStructTag(sf reflect.StructField) (*structField, error) {
+	res := &structField{
+		name:   kebabCase(sf.Name),
+		prefix: kebabCase(sf.Name),
+	}
+	tag, ok := sf.Tag.Lookup("cli")
+	if !ok {
+		return res, nil
+	}
+	if tag == "-" {
+		res.skip = true
+		return res, nil
+	}
+
+	for _, setting := range splitStructTag(tag) {
+		key, value, _ := strings.Cut(setting, "=")
+		value = strings.Trim(value, "'")
+		switch strings.TrimSpace(key) {
+		case "name":
+			res.name = value
+			res.prefix = value
+		case "alias":
+			res.aliases = append(res.aliases, value)
+		case "env":
+			res.envVars = append(res.envVars, value)
+		case "help":
+			res.help = value
+		case "category":
+			res.category = value
+		case "prefix":
+			res.prefix = value
+		case "required":
+			res.required = true
+		case "hidden":
+			res.hidden = true
+		case "arg":
+			res.arg = true
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown setting %q in cli tag of field %s", key, sf.Name)
+		}
+	}
+	return res, nil
+}
+
+// splitStructTag splits on commas which are not within single quotes
+func splitStructTag(tag string) []string {
+	var (
+		res    []string
+		quoted bool
+		start  int
+	)
+	for i, r := range tag {
+		switch r {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				res = append(res, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(res, tag[start:])
+}
+
+// kebabCase converts a Go identifier to lowercase words separated by hyphens.  Runs
+// of capitals are treated as acronyms, so HTTPPort is http-port.
+func kebabCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteRune('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"net/netip"
+	"net/url"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type serverOptions struct {
+	URL     *url.URL `cli:"help=URL of the server"`
+	Timeout time.Duration
+}
+
+type commonOptions struct {
+	Verbose bool `cli:"alias=v"`
+}
+
+type structOptions struct {
+	commonOptions
+
+	Output    string            `cli:"name=output,alias=o,env=APP_OUTPUT,help='Write output to FILE, then exit',required,category=Output"`
+	MaxCount  int               `cli:"category=Output"`
+	Labels    map[string]string `cli:"hidden"`
+	Size      *cli.ByteSizeValue
+	Since     cli.TimeValue
+	Server    serverOptions `cli:"category=Server"`
+	Mirror    serverOptions `cli:"prefix=mirror"`
+	Files     []string      `cli:"arg,help=Files to process"`
+	Ignored   string        `cli:"-"`
+	unexposed string
+}
+
+var _ = Describe("FlagsFromStruct", func() {
+
+	It("creates flags from fields", func() {
+		var opts structOptions
+		flags := cli.FlagsFromStruct(&opts)
+
+		Expect(flags).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("verbose"),
+				"Aliases": Equal([]string{"v"}),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":     Equal("output"),
+				"Aliases":  Equal([]string{"o"}),
+				"EnvVars":  Equal([]string{"APP_OUTPUT"}),
+				"HelpText": Equal("Write output to FILE, then exit"),
+				"Category": Equal("Output"),
+				"Options":  Equal(cli.Required),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":     Equal("max-count"),
+				"Category": Equal("Output"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("labels"),
+				"Options": Equal(cli.Hidden),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("size"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("since"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":     Equal("server-url"),
+				"HelpText": Equal("URL of the server"),
+				"Category": Equal("Server"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":     Equal("server-timeout"),
+				"Category": Equal("Server"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("mirror-url"),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("mirror-timeout"),
+			})),
+		))
+	})
+
+	It("creates args from fields", func() {
+		var opts structOptions
+		args := cli.ArgsFromStruct(&opts)
+
+		Expect(args).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name":     Equal("files"),
+				"HelpText": Equal("Files to process"),
+			})),
+		))
+	})
+
+	It("binds the fields", func() {
+		opts := structOptions{
+			Size:     cli.ByteSize(),
+			MaxCount: 10,
+		}
+		app := &cli.App{
+			Name:   "app",
+			Flags:  cli.FlagsFromStruct(&opts),
+			Args:   cli.ArgsFromStruct(&opts),
+			Action: func() {},
+		}
+
+		args, _ := cli.Split("app -v -o out.txt --size 2KiB --server-url https://example.com --mirror-timeout 5s --labels a=b a.txt b.txt")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		Expect(opts.Verbose).To(BeTrue())
+		Expect(opts.Output).To(Equal("out.txt"))
+		Expect(opts.MaxCount).To(Equal(10))
+		Expect(opts.Size.Value()).To(Equal(int64(2048)))
+		Expect(opts.Server.URL.String()).To(Equal("https://example.com"))
+		Expect(opts.Mirror.Timeout).To(Equal(5 * time.Second))
+		Expect(opts.Labels).To(Equal(map[string]string{"a": "b"}))
+		Expect(opts.Files).To(Equal([]string{"a.txt", "b.txt"}))
+	})
+
+	It("binds time, address, and pointer fields", func() {
+		opts := struct {
+			Since  time.Time
+			Until  *time.Time
+			Listen netip.AddrPort
+			Subnet netip.Prefix
+			Peer   netip.Addr
+			Server *serverOptions
+		}{
+			Listen: netip.MustParseAddrPort("127.0.0.1:8080"),
+		}
+		app := &cli.App{
+			Name:   "app",
+			Flags:  cli.FlagsFromStruct(&opts),
+			Action: func() {},
+		}
+
+		args, _ := cli.Split("app --since @1700000000 --until @1700000001 --subnet 10.0.0.0/8 --peer 10.0.0.1 --server-timeout 5s")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		Expect(opts.Since).To(BeTemporally("==", time.Unix(1700000000, 0)))
+		Expect(*opts.Until).To(BeTemporally("==", time.Unix(1700000001, 0)))
+		Expect(opts.Server.Timeout).To(Equal(5 * time.Second))
+		Expect(opts.Listen).To(Equal(netip.MustParseAddrPort("127.0.0.1:8080")))
+		Expect(opts.Subnet).To(Equal(netip.MustParsePrefix("10.0.0.0/8")))
+		Expect(opts.Peer).To(Equal(netip.MustParseAddr("10.0.0.1")))
+
+		flag, _ := app.Flag("since")
+		Expect(flag.Synopsis()).To(Equal("--since=TIME"))
+	})
+
+	It("requires the flags which are required", func() {
+		var opts structOptions
+		app := &cli.App{
+			Name:  "app",
+			Flags: cli.FlagsFromStruct(&opts),
+			Args:  cli.ArgsFromStruct(&opts),
+		}
+
+		args, _ := cli.Split("app")
+		Expect(app.RunContext(context.Background(), args)).To(MatchError(ContainSubstring("--output")))
+	})
+
+	DescribeTable("panics",
+		func(v any, expected string) {
+			Expect(func() { cli.FlagsFromStruct(v) }).To(PanicWith(expected))
+		},
+		Entry("not a pointer", structOptions{}, "expected pointer to struct, but got cli_test.structOptions"),
+		Entry("unsupported type", &struct{ C chan int }{}, "unsupported type chan int for field C"),
+		Entry("struct without exported fields", &struct{ S struct{ s int } }{}, "unsupported type struct { s int } for field S"),
+	)
+})
@@ -41,6 +41,7 @@ type flagData struct {
 	HelpText    string
 	ManualText  string
 	DefaultText string
+	Sources     []string
 	Description any
 	Data        map[string]any
 }
@@ -57,6 +58,14 @@ type flagDataCategory struct {
 	VisibleFlags flagDataList
 }
 
+const flagSourcesTemplate = `
+{{- define "FlagSources" -}}
+{{- if .Sources -}}
+{{ " " }}[{{ .Sources | Join ", " }}]
+{{- end -}}
+{{- end -}}
+`
+
 var (
 	// HelpTemplate provides the default help Go template that is rendered on the help
 	// screen.  The preferred way to customize the help screen is to override its constituent
@@ -84,8 +93,12 @@ var (
 {{- define "Flag" -}}
 {{ "\t" }}{{ .Synopsis | print | ExtraSpaceBeforeFlag }}{{ "\t" }}{{.HelpText}}
 {{- if .DefaultText -}}
- (default: {{.DefaultText}})
+{{ " " }}(default: {{.DefaultText}})
+{{- end -}}
+{{- template "FlagSources" . -}}
 {{- end -}}
+
+{{- define "FlagSources" -}}
 {{- end -}}
 
 {{- define "Flags" -}}
@@ -326,6 +339,7 @@ func flagAdapter(val *Flag) *flagData {
 		ManualText:  val.ManualText,
 		Description: val.Description,
 		DefaultText: val.DefaultText,
+		Sources:     flagSources(val),
 		Synopsis:    wrapSynopsis(syn),
 		Data:        val.Data,
 	}
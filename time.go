@@ -0,0 +1,247 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeValue provides a value which parses a point in time.  The following
+// syntaxes are supported:
+//
+//   - a time or date using one of the layouts, which by default are RFC 3339 and
+//     the date and time layouts 2006-01-02 15:04:05, 2006-01-02T15:04:05,
+//     2006-01-02 15:04, and 2006-01-02
+//   - a Unix epoch timestamp in seconds prefixed with @, such as @1700000000 or
+//     @1700000000.5
+//   - a relative expression which is a signed duration added to the current time,
+//     such as -2h or +30m
+//   - the keywords now, today, yesterday, and tomorrow, where the latter three
+//     denote midnight of the corresponding day
+//
+// Times which do not specify a time zone are interpreted in the local time zone
+// unless the time zone was set by the flag provided by TimeZone.
+type TimeValue struct {
+	text    string
+	layouts []string
+	loc     *time.Location
+	value   time.Time
+}
+
+type timeZone struct {
+	loc *time.Location
+}
+
+const timeZoneFlagName = "tz"
+
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// Time creates a time value.  The layouts specify the layouts that are accepted,
+// using the same syntax as time.Parse.  If no layouts are specified, the defaults
+// described in TimeValue are used.  Epoch timestamps, relative expressions, and keywords
+// are always accepted.
+func Time(layouts ...string) *TimeValue {
+	return &TimeValue{
+		layouts: layouts,
+	}
+}
+
+// TimeZone provides a flag named tz which sets the time zone used to interpret
+// and display time values (see Time).  The value is the name of a location in the
+// IANA Time Zone database such as America/New_York, UTC, or Local.  Because the flag
+// is persistent, it applies to time values in sub-commands.  It is typically used
+// in the Uses pipeline of a flag:
+//
+//	Flags: []*cli.Flag{
+//		{Uses: cli.TimeZone()},
+//	}
+func TimeZone() Action {
+	return &Prototype{
+		Name:     timeZoneFlagName,
+		HelpText: "Interpret and display times using the specified time `ZONE`",
+		Value:    new(timeZone),
+	}
+}
+
+// Set will parse the time
+func (t *TimeValue) Set(arg string) error {
+	value, err := parseTime(arg, t.actualLayouts(), t.Location(), time.Now())
+	if err != nil {
+		return err
+	}
+	t.text = arg
+	t.value = value
+	return nil
+}
+
+// String gets the time formatted using RFC 3339
+func (t *TimeValue) String() string {
+	if t.value.IsZero() {
+		return ""
+	}
+	return t.value.Format(time.RFC3339)
+}
+
+// Value obtains the time
+func (t *TimeValue) Value() time.Time {
+	return t.value
+}
+
+// Location gets the time zone used to interpret times, which is the local
+// time zone by default
+func (t *TimeValue) Location() *time.Location {
+	if t.loc == nil {
+		return time.Local
+	}
+	return t.loc
+}
+
+// SetLocation sets the time zone used to interpret times.  If a time was already
+// set, it is interpreted again using the time zone.
+func (t *TimeValue) SetLocation(loc *time.Location) error {
+	t.loc = loc
+	if t.text == "" {
+		return nil
+	}
+	return t.Set(t.text)
+}
+
+// Reset sets the value back to the zero time
+func (t *TimeValue) Reset() {
+	t.text = ""
+	t.value = time.Time{}
+}
+
+// Copy creates a copy of the time value
+func (t *TimeValue) Copy() *TimeValue {
+	res := *t
+	return &res
+}
+
+// Synopsis obtains the synopsis text
+func (*TimeValue) Synopsis() string {
+	return "TIME"
+}
+
+// Initializer obtains the initializer for the time value, which applies the time zone
+// from the flag provided by TimeZone when it is in scope
+func (t *TimeValue) Initializer() Action {
+	return ActionFunc(func(c *Context) error {
+		return c.Before(ActionFunc(t.applyTimeZone))
+	})
+}
+
+func (t *TimeValue) applyTimeZone(c *Context) error {
+	if loc, ok := c.Value(timeZoneFlagName).(*time.Location); ok && loc != nil {
+		return t.SetLocation(loc)
+	}
+	return nil
+}
+
+func (t *TimeValue) actualLayouts() []string {
+	if len(t.layouts) == 0 {
+		return defaultTimeLayouts
+	}
+	return t.layouts
+}
+
+func (t *TimeValue) setDirect(v any) error {
+	t.text = ""
+	t.value = v.(time.Time)
+	return nil
+}
+
+func (z *timeZone) Set(arg string) error {
+	loc, err := time.LoadLocation(arg)
+	if err != nil {
+		return fmt.Errorf("invalid time zone: %s", arg)
+	}
+	z.loc = loc
+	return nil
+}
+
+func (z *timeZone) String() string {
+	if z.loc == nil {
+		return ""
+	}
+	return z.loc.String()
+}
+
+func (z *timeZone) Value() *time.Location {
+	return z.loc
+}
+
+func (*timeZone) Synopsis() string {
+	return "ZONE"
+}
+
+func parseTime(s string, layouts []string, loc *time.Location, now time.Time) (time.Time, error) {
+	text := strings.TrimSpace(s)
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch strings.ToLower(text) {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		if d, err := time.ParseDuration(text); err == nil {
+			return now.Add(d), nil
+		}
+	}
+
+	if epoch, ok := strings.CutPrefix(text, "@"); ok && isEpoch(epoch) {
+		f, err := strconv.ParseFloat(epoch, 64)
+		if err == nil {
+			sec := int64(f)
+			nsec := int64((f - float64(sec)) * float64(time.Second))
+			return time.Unix(sec, nsec).In(loc), nil
+		}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
+
+func isEpoch(s string) bool {
+	if s == "" {
+		return false
+	}
+	var dot bool
+	for _, r := range s {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r < '0' || r > '9':
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	_ Value = (*TimeValue)(nil)
+	_ Value = (*timeZone)(nil)
+)
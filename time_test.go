@@ -0,0 +1,162 @@
+// Copyright 2026 The Joe-cli Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli_test
+
+import (
+	"context"
+	"time"
+
+	cli "github.com/Carbonfrost/joe-cli"
+	"github.com/Carbonfrost/joe-cli/extensions/bind"
+	joeclifakes "github.com/Carbonfrost/joe-cli/joe-clifakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("Time", func() {
+
+	DescribeTable("examples",
+		func(v *cli.TimeValue, text string, expected types.GomegaMatcher) {
+			Expect(v.SetLocation(time.UTC)).To(Succeed())
+			Expect(v.Set(text)).To(Succeed())
+			Expect(v.Value()).To(expected)
+		},
+		Entry(
+			"RFC 3339",
+			cli.Time(),
+			"2024-03-01T10:30:00Z",
+			BeTemporally("==", time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)),
+		),
+		Entry(
+			"RFC 3339 with offset",
+			cli.Time(),
+			"2024-03-01T10:30:00+02:00",
+			BeTemporally("==", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)),
+		),
+		Entry(
+			"date",
+			cli.Time(),
+			"2024-03-01",
+			BeTemporally("==", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		),
+		Entry(
+			"date and time",
+			cli.Time(),
+			"2024-03-01 10:30",
+			BeTemporally("==", time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)),
+		),
+		Entry(
+			"epoch",
+			cli.Time(),
+			"@1700000000",
+			BeTemporally("==", time.Unix(1700000000, 0)),
+		),
+		Entry(
+			"epoch fraction",
+			cli.Time(),
+			"@1700000000.5",
+			BeTemporally("==", time.Unix(1700000000, 500000000)),
+		),
+		Entry(
+			"relative",
+			cli.Time(),
+			"-2h",
+			BeTemporally("~", time.Now().Add(-2*time.Hour), time.Minute),
+		),
+		Entry(
+			"now",
+			cli.Time(),
+			"now",
+			BeTemporally("~", time.Now(), time.Minute),
+		),
+		Entry(
+			"yesterday",
+			cli.Time(),
+			"yesterday",
+			BeTemporally("==", time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)),
+		),
+		Entry(
+			"custom layout",
+			cli.Time("02/01/2006"),
+			"15/04/2024",
+			BeTemporally("==", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)),
+		),
+	)
+
+	DescribeTable("errors",
+		func(v *cli.TimeValue, text string) {
+			Expect(v.Set(text)).To(MatchError("invalid time: " + text))
+		},
+		Entry("invalid", cli.Time(), "not a time"),
+		Entry("layout not allowed", cli.Time("02/01/2006"), "2024-03-01"),
+		Entry("digits without epoch prefix", cli.Time(), "20240101"),
+		Entry("epoch prefix without digits", cli.Time(), "@"),
+	)
+
+	It("applies the time zone flag to sub-commands", func() {
+		act := new(joeclifakes.FakeAction)
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Uses: cli.TimeZone()},
+			},
+			Commands: []*cli.Command{
+				{
+					Name: "logs",
+					Flags: []*cli.Flag{
+						{Name: "since", Value: cli.Time()},
+					},
+					Action: act,
+				},
+			},
+		}
+
+		args, _ := cli.Split("app --tz America/New_York logs --since 2024-03-01")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+
+		ny, _ := time.LoadLocation("America/New_York")
+		captured := cli.FromContext(act.ExecuteArgsForCall(0))
+		Expect(captured.Time("since")).To(BeTemporally("==", time.Date(2024, 3, 1, 0, 0, 0, 0, ny)))
+		Expect(captured.Time("since").Location()).To(Equal(ny))
+	})
+
+	It("returns an error for an invalid time zone", func() {
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{Uses: cli.TimeZone()},
+			},
+		}
+
+		args, _ := cli.Split("app --tz Nowhere/Special")
+		Expect(app.RunContext(context.Background(), args)).To(MatchError(ContainSubstring("invalid time zone: Nowhere/Special")))
+	})
+
+	It("can be bound", func() {
+		var actual time.Time
+		app := &cli.App{
+			Name: "app",
+			Flags: []*cli.Flag{
+				{
+					Name: "until",
+					Uses: bind.Call(func(t time.Time) error {
+						actual = t
+						return nil
+					}, bind.Time()),
+				},
+			},
+		}
+
+		args, _ := cli.Split("app --until @1700000000")
+		Expect(app.RunContext(context.Background(), args)).To(Succeed())
+		Expect(actual).To(BeTemporally("==", time.Unix(1700000000, 0)))
+	})
+
+	It("has synopsis", func() {
+		f := &cli.Flag{Name: "since", Value: cli.Time()}
+		Expect(f.Synopsis()).To(Equal("--since=TIME"))
+	})
+})
@@ -352,6 +352,41 @@ var _ = Describe("DisplayHelpScreen", func() {
 				},
 			},
 			ContainSubstring("(default: easy)")),
+		Entry("separate default text from help text",
+			&cli.App{
+				Flags: []*cli.Flag{
+					{
+						Name:        "s",
+						HelpText:    "Sets the level",
+						DefaultText: "easy",
+					},
+				},
+			},
+			ContainSubstring("Sets the level (default: easy)")),
+		Entry("omit value sources by default",
+			&cli.App{
+				Flags: []*cli.Flag{
+					{
+						Name:     "s",
+						HelpText: "Sets the level",
+						EnvVars:  []string{"LEVEL"},
+					},
+				},
+			},
+			Not(ContainSubstring("env: LEVEL"))),
+		Entry("display value sources",
+			&cli.App{
+				Uses: cli.DisplayValueSources(),
+				Flags: []*cli.Flag{
+					{
+						Name:     "s",
+						HelpText: "Sets the level",
+						EnvVars:  []string{"LEVEL"},
+						Uses:     cli.DeclareValueSource(cli.ValueSource{Kind: cli.ConfigSource, Name: "level"}),
+					},
+				},
+			},
+			ContainSubstring("Sets the level [env: LEVEL, config: level]")),
 		Entry("display expression",
 			&cli.App{
 				Args: cli.Args("expression", &expr.Expression{}),
@@ -85,6 +85,10 @@ type valueResetOrMerge interface {
 	Reset()
 }
 
+type valueSetDirect interface {
+	setDirect(any) error
+}
+
 type valueProvidesCounter interface {
 	NewCounter() ArgCounter
 }
@@ -619,6 +623,8 @@ func setDirect(dest any, v any) error {
 		*p = v.(*big.Float)
 	case *[]byte:
 		*p = v.([]byte)
+	case valueSetDirect:
+		return p.setDirect(v)
 	default:
 		panic(fmt.Sprintf("cannot set value directly: %T %v", dest, v))
 	}
@@ -894,6 +900,8 @@ func optionApplyValueConventions(p any, flags internalFlags, firstOccur bool) {
 			switch p := p.(type) {
 			case *string:
 				*p = ""
+			case CollectionValue:
+				// collections accumulate across occurrences
 			case valueResetOrMerge:
 				p.Reset()
 			}
@@ -11,7 +11,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math"
 	"math/big"
 	"net"
 	"net/url"
@@ -30,7 +29,8 @@ type Hex int
 // Octal represents an integer that parses from the octal syntax
 type Octal int
 
-// ByteLength represents number of bytes
+// ByteLength represents number of bytes.  It uses the same syntax as
+// cli.ByteSize, which is preferred because it supports bounds.
 type ByteLength int
 
 type jsonValue struct {
@@ -47,46 +47,14 @@ func JSON(v any) flag.Getter {
 	}
 }
 
-var (
-	byteLengthPat = regexp.MustCompile(`([0-9.]+)\s*([kKMGTPEZYRQ]i?)?B`)
-	magnitude     = map[string]int{
-		"":  0,
-		"k": 1,
-		"K": 1,
-		"M": 2,
-		"G": 3,
-		"T": 4,
-		"P": 5,
-		"E": 6,
-		"Z": 7,
-		"Y": 8,
-		"R": 9,
-		"Q": 10,
-	}
-)
-
-// ParseByteLength from a string
+// ParseByteLength from a string.  The syntax is the same as cli.ByteSize, which
+// allows a decimal (kB, MB, GB, ...) or binary (KiB, MiB, GiB, ...) unit.
 func ParseByteLength(s string) (int, error) {
-	s = strings.TrimSpace(s)
-	if !strings.HasSuffix(s, "B") {
-		return strconv.Atoi(s)
-	}
-
-	sub := byteLengthPat.FindSubmatch([]byte(s))
-	if len(sub) == 0 {
-		return -1, fmt.Errorf("invalid byte length")
-	}
-	if len(sub[2]) == 0 {
-		return strconv.Atoi(string(sub[1]))
-	}
-
-	num, _ := strconv.ParseFloat(string(sub[1]), 64)
-	var base float64 = 1000
-	if strings.HasSuffix(string(sub[2]), "i") {
-		base = 1024
+	res, err := support.ParseByteSize(s)
+	if err != nil {
+		return -1, err
 	}
-	magnitude := magnitude[string(sub[2][0])]
-	return int(num * math.Pow(base, float64(magnitude))), nil
+	return int(res), nil
 }
 
 func (b *ByteLength) UnmarshalText(data []byte) error {